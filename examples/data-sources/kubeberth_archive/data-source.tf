@@ -0,0 +1,11 @@
+data "kubeberth_archive" "golden" {
+  name = "ubuntu-20.04-golden"
+}
+
+resource "kubeberth_disk" "terraform-example" {
+  name   = "terraform-example"
+  size   = "16Gi"
+  source = {
+    archive = data.kubeberth_archive.golden.name
+  }
+}
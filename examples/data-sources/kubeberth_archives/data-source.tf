@@ -0,0 +1,3 @@
+data "kubeberth_archives" "ubuntu" {
+  name_regex = "^ubuntu-"
+}
@@ -0,0 +1 @@
+data "kubeberth_cloudinits" "all" {}
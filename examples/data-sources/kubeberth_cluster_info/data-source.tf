@@ -0,0 +1,5 @@
+data "kubeberth_cluster_info" "this" {}
+
+output "schedulable_nodes" {
+  value = data.kubeberth_cluster_info.this.nodes
+}
@@ -0,0 +1,3 @@
+data "kubeberth_disk" "terraform-example" {
+  name = "terraform-example"
+}
@@ -0,0 +1,3 @@
+data "kubeberth_disks" "large" {
+  min_size = "100Gi"
+}
@@ -0,0 +1 @@
+data "kubeberth_isoimages" "all" {}
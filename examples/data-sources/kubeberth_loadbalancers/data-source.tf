@@ -0,0 +1,3 @@
+data "kubeberth_loadbalancers" "web" {
+  backend = "terraform-example"
+}
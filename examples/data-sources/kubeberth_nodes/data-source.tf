@@ -0,0 +1,12 @@
+data "kubeberth_nodes" "arm64" {
+  architecture     = "arm64"
+  schedulable_only = true
+}
+
+resource "kubeberth_server" "terraform-example" {
+  name     = "terraform-example"
+  cpu      = 2
+  memory   = "2Gi"
+  hostname = "terraform-example-server"
+  hosting  = data.kubeberth_nodes.arm64.nodes[0].name
+}
@@ -0,0 +1,3 @@
+data "kubeberth_server" "terraform-example" {
+  name = "terraform-example"
+}
@@ -0,0 +1,4 @@
+data "kubeberth_servers" "node-3" {
+  hosting = "node-3.k8s.home.arpa"
+  running = true
+}
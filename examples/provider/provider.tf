@@ -10,4 +10,8 @@ terraform {
 
 provider "kubeberth" {
   url = "http://api.kubeberth.k8s.arpa/api/v1alpha1/"
+
+  default_labels = {
+    "app.kubernetes.io/managed-by" = "terraform"
+  }
 }
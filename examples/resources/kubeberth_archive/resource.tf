@@ -2,3 +2,8 @@ resource "kubeberth_archive" "terraform-example" {
   name       = "terraform-example"
   repository = "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04-server-cloudimg-arm64.img"
 }
+
+resource "kubeberth_archive" "terraform-example-upload" {
+  name        = "terraform-example-upload"
+  source_file = "${path.module}/images/ubuntu-20.04-server-cloudimg-arm64.img"
+}
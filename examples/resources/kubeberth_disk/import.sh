@@ -0,0 +1,2 @@
+# Import by namespace/name, or by name alone to use the provider namespace.
+terraform import kubeberth_disk.terraform-example default/terraform-example
@@ -0,0 +1,2 @@
+# Import by namespace/server/disk, or by server/disk to use the provider namespace.
+terraform import kubeberth_disk_attachment.data default/terraform-example/terraform-example-data
@@ -0,0 +1,9 @@
+resource "kubeberth_disk" "data" {
+  name = "terraform-example-data"
+  size = "100Gi"
+}
+
+resource "kubeberth_disk_attachment" "data" {
+  server = "terraform-example"
+  disk   = kubeberth_disk.data.name
+}
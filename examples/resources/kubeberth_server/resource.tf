@@ -6,9 +6,25 @@ resource "kubeberth_server" "terraform-example" {
   mac_address = "52:42:00:11:22:33"
   hostname    = "terraform-example-server"
   hosting     = "node-1.k8s.home.arpa"
-  disk        = {
-    name = "terraformexaample"
-  }
+  network_interfaces = [
+    {
+      network = "default"
+      primary = true
+    },
+    {
+      network     = "storage"
+      mac_address = "52:42:00:11:22:34"
+      model       = "virtio"
+    },
+  ]
+  disks       = [
+    {
+      name       = "terraformexaample"
+      boot_order = 1
+      bus        = "virtio"
+      serial     = "terraform-root"
+    },
+  ]
   cloudinit   = {
     name = "terraform-example"
   }
@@ -0,0 +1,10 @@
+resource "kubeberth_server_power" "terraform-example" {
+  server           = "terraform-example"
+  state            = "running"
+  shutdown_timeout = "2m"
+
+  # Restart the server whenever its cloudinit changes.
+  restart_trigger = {
+    user_data = kubeberth_cloudinit.terraform-example.user_data
+  }
+}
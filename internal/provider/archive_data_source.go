@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = archiveDataSourceType{}
+var _ tfsdk.DataSource = archiveDataSource{}
+
+type archiveDataSourceType struct{}
+
+func (t archiveDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := archiveAttributes()
+	attributes["name"] = tfsdk.Attribute{
+		MarkdownDescription: "name",
+		Type:                types.StringType,
+		Required:            true,
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing kubeberth archive by name.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (t archiveDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return archiveDataSource{
+		provider: provider,
+	}, diags
+}
+
+type archiveDataSource struct {
+	provider provider
+}
+
+func (d archiveDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var config archiveDataSourceData
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	archive, err := d.provider.client.GetArchive(ctx, config.Name.Value)
+	tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", archive))
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("name"),
+			"Archive Not Found",
+			fmt.Sprintf("Unable to read archive %q, got error: %s\n\nCheck that the archive exists and that its name is spelled correctly.", config.Name.Value, err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read archive %q, got error: %s", config.Name.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, newArchiveDataSourceData(archive))
+	resp.Diagnostics.Append(diags...)
+}
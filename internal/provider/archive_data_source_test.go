@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccArchiveDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccArchiveDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_archive.test", "name", "terraform-acc-archive"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_archive.test", "repository", "kubeberth_archive.test", "repository"),
+				),
+			},
+			// Missing archive testing
+			{
+				Config:      testAccArchiveDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("Archive Not Found"),
+			},
+		},
+	})
+}
+
+const testAccArchiveDataSourceConfig = `
+resource "kubeberth_archive" "test" {
+  name       = "terraform-acc-archive"
+  repository = "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04-server-cloudimg-arm64.img"
+}
+
+data "kubeberth_archive" "test" {
+  name = kubeberth_archive.test.name
+}
+`
+
+const testAccArchiveDataSourceMissingConfig = `
+data "kubeberth_archive" "test" {
+  name = "terraform-acc-does-not-exist"
+}
+`
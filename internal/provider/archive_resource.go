@@ -25,18 +25,29 @@ func (t archiveResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(addImportAttributes(addSourceFileAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
 				Required:            true,
 			},
 			"repository": {
-				MarkdownDescription: "repository",
+				MarkdownDescription: "URL to import the image from: `http://`, `https://`, `s3://bucket/key` or `oci://registry/repository:tag`. Conflicts with `source_file`.",
 				Type:                types.StringType,
-				Required:            true,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					repositoryURL(),
+				},
+			},
+			"size": {
+				MarkdownDescription: "Size of the imported image.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
 			},
-		},
+		})))),
 	}, nil
 }
 
@@ -51,6 +62,22 @@ func (t archiveResourceType) NewResource(ctx context.Context, in tfsdk.Provider)
 type archiveResourceData struct {
 	Name       types.String `tfsdk:"name"`
 	Repository types.String `tfsdk:"repository"`
+
+	Size          types.String `tfsdk:"size"`
+	Checksum      types.String `tfsdk:"checksum"`
+	State         types.String `tfsdk:"state"`
+	ImportTimeout types.String `tfsdk:"import_timeout"`
+
+	RepositoryCredentials *repositoryCredentialsData `tfsdk:"repository_credentials"`
+
+	SourceFile     types.String `tfsdk:"source_file"`
+	SourceFileHash types.String `tfsdk:"source_file_hash"`
+	SourceFileStat types.String `tfsdk:"source_file_stat"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
 }
 
 type archiveResource struct {
@@ -61,15 +88,68 @@ func createNewArchive(data *archiveResourceData) *kubeberth.RequestArchive {
 	archive := &kubeberth.RequestArchive{
 		Name:       data.Name.Value,
 		Repository: data.Repository.Value,
+
+		RepositoryCredentials: newRepositoryCredentials(data.RepositoryCredentials),
 	}
 
 	return archive
 }
 
+// awaitImport waits for kubeberth to import the archive, records the result
+// in data and verifies the configured checksum.
+func (r archiveResource) awaitImport(ctx context.Context, data *archiveResourceData) error {
+	client := r.provider.namespacedClient(data.Namespace)
+
+	status, err := waitForImport(ctx, "archive", data.Name.Value, importTimeout(data.ImportTimeout), func(ctx context.Context) (*importStatus, error) {
+		archive, err := client.GetArchive(ctx, data.Name.Value)
+		tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", archive))
+		if err != nil {
+			return nil, err
+		}
+
+		return &importStatus{
+			State:    archive.State,
+			Progress: archive.Progress,
+			Message:  archive.Message,
+			Size:     archive.Size,
+			Checksum: archive.Checksum,
+		}, nil
+	})
+	if status == nil {
+		status = &importStatus{}
+	}
+
+	data.State = types.String{Null: status.State == "", Value: status.State}
+	if data.Size.Unknown {
+		data.Size = types.String{Null: status.Size == "", Value: status.Size}
+	}
+	if data.Checksum.Unknown {
+		data.Checksum = refreshChecksum(data.Checksum, status.Checksum)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return verifyChecksum("archive", data.Name.Value, data.Checksum, status.Checksum)
+}
+
+func (r archiveResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	validateImageSource(ctx, req.Config, &resp.Diagnostics)
+	validateRepositoryCredentials(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r archiveResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+	modifyPlanSourceFile(ctx, []string{"size", "checksum"}, req, resp)
+	modifyPlanImport(ctx, []string{"size", "checksum"}, req, resp)
+}
+
 func (r archiveResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data archiveResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -85,13 +165,34 @@ func (r archiveResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 	// }
 
 	newArchive := createNewArchive(&data)
-	createdArchive, err := r.provider.client.CreateArchive(ctx, newArchive)
+	newArchive.Labels, newArchive.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	newArchive.Namespace = data.Namespace.Value
+
+	if !data.SourceFile.Null {
+		upload, err := uploadImage(ctx, r.provider.namespacedClient(data.Namespace), "archive", data.Name.Value, data.SourceFile.Value, data.SourceFileHash.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Unable to upload %s, got error: %s", data.SourceFile.Value, err))
+			return
+		}
+		newArchive.Upload = upload
+	}
+
+	createdArchive, err := r.provider.namespacedClient(data.Namespace).CreateArchive(ctx, newArchive)
 	tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", createdArchive))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create archive, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, createdArchive.Namespace)
+
+	// Save the archive even if the import fails, so that it is replaced on the
+	// next apply rather than leaked.
+	if err := r.awaitImport(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Import Error", err.Error())
+	}
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -124,13 +225,21 @@ func (r archiveResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 	//     return
 	// }
 
-	archive, err := r.provider.client.GetArchive(ctx, data.Name.Value)
+	archive, err := r.provider.namespacedClient(data.Namespace).GetArchive(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", archive))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read archive, got error: %s", err))
 		return
 	}
 
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, archive.Labels, archive.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, archive.Namespace)
+
+	data.Repository = types.String{Null: archive.Repository == "", Value: archive.Repository}
+	data.State = types.String{Null: archive.State == "", Value: archive.State}
+	data.Size = types.String{Null: archive.Size == "", Value: archive.Size}
+	data.Checksum = refreshChecksum(data.Checksum, archive.Checksum)
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -138,11 +247,14 @@ func (r archiveResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 }
 
 func (r archiveResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data archiveResourceData
+	var data, state archiveResourceData
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -156,13 +268,32 @@ func (r archiveResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 	// }
 
 	newArchive := createNewArchive(&data)
-	updatedArchive, err := r.provider.client.UpdateArchive(ctx, data.Name.Value, newArchive)
+	newArchive.Labels, newArchive.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	newArchive.Namespace = data.Namespace.Value
+
+	updatedArchive, err := r.provider.namespacedClient(data.Namespace).UpdateArchive(ctx, data.Name.Value, newArchive)
 	tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", updatedArchive))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update archive, got error: %s", err))
 		return
 	}
 
+	// Changing only labels or annotations leaves the imported image, or a
+	// failed import, alone.
+	if importPending(state.State, data.Repository, state.Repository, data.SourceFileHash, state.SourceFileHash) {
+		if err := r.awaitImport(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Import Error", err.Error())
+		}
+	} else {
+		data.State = state.State
+		if data.Size.Unknown {
+			data.Size = state.Size
+		}
+		if data.Checksum.Unknown {
+			data.Checksum = state.Checksum
+		}
+	}
+
 	tflog.Trace(ctx, "updated a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -187,7 +318,7 @@ func (r archiveResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteArchive(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteArchive(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("archive: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete archive, got error: %s", err))
@@ -196,5 +327,5 @@ func (r archiveResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 }
 
 func (r archiveResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
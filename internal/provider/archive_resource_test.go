@@ -7,35 +7,33 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccArchiveResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccArchiveResourceConfig("http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04-server-cloudimg-arm64.img"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("scaffolding_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("kubeberth_archive.test", "name", "terraform-acc-archive"),
+					resource.TestCheckResourceAttr("kubeberth_archive.test", "state", "Created"),
+					resource.TestMatchResourceAttr("kubeberth_archive.test", "checksum", checksumRegexp),
+					resource.TestCheckResourceAttr("kubeberth_archive.test", "repository", "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04-server-cloudimg-arm64.img"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "scaffolding_example.test",
+				ResourceName:      "kubeberth_archive.test",
 				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_archive.test", "name"),
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccArchiveResourceConfig("http://minio.home.arpa:9000/kubevirt/images/ubuntu-22.04-server-cloudimg-arm64.img"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("kubeberth_archive.test", "repository", "http://minio.home.arpa:9000/kubevirt/images/ubuntu-22.04-server-cloudimg-arm64.img"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -43,10 +41,11 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func testAccArchiveResourceConfig(repository string) string {
 	return fmt.Sprintf(`
-resource "scaffolding_example" "test" {
-  configurable_attribute = %[1]q
+resource "kubeberth_archive" "test" {
+  name       = "terraform-acc-archive"
+  repository = %[1]q
 }
-`, configurableAttribute)
+`, repository)
 }
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = archivesDataSourceType{}
+var _ tfsdk.DataSource = archivesDataSource{}
+
+type archivesDataSourceType struct{}
+
+func (t archivesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["state"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return archives in this state.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["archives"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching archives, sorted by name.",
+		Computed:            true,
+		Attributes:          tfsdk.ListNestedAttributes(archiveAttributes(), tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth archives.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+// archiveAttributes returns the computed attributes describing an archive.
+func archiveAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name": {
+			MarkdownDescription: "name",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"repository": {
+			MarkdownDescription: "repository",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"size": {
+			MarkdownDescription: "size",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"state": {
+			MarkdownDescription: "state",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"checksum": {
+			MarkdownDescription: "checksum",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (t archivesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return archivesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type archiveDataSourceData struct {
+	Name       types.String `tfsdk:"name"`
+	Repository types.String `tfsdk:"repository"`
+	Size       types.String `tfsdk:"size"`
+	State      types.String `tfsdk:"state"`
+	Checksum   types.String `tfsdk:"checksum"`
+}
+
+func newArchiveDataSourceData(archive *kubeberth.ResponseArchive) *archiveDataSourceData {
+	return &archiveDataSourceData{
+		Name:       types.String{Value: archive.Name},
+		Repository: types.String{Value: archive.Repository},
+		Size:       types.String{Value: archive.Size},
+		State:      types.String{Value: archive.State},
+		Checksum:   types.String{Value: strings.ToLower(archive.Checksum)},
+	}
+}
+
+type archivesDataSourceData struct {
+	NameRegex types.String            `tfsdk:"name_regex"`
+	Labels    map[string]string       `tfsdk:"labels"`
+	State     types.String            `tfsdk:"state"`
+	Archives  []archiveDataSourceData `tfsdk:"archives"`
+}
+
+// match reports whether archive passes the filters of the data source.
+func (data *archivesDataSourceData) match(filter *listFilter, archive *kubeberth.ResponseArchive) bool {
+	if !filter.match(archive.Name, archive.Labels) {
+		return false
+	}
+	if !data.State.Null && archive.State != data.State.Value {
+		return false
+	}
+
+	return true
+}
+
+type archivesDataSource struct {
+	provider provider
+}
+
+func (d archivesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data archivesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseArchives, err := d.provider.client.GetAllArchives(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("archives: %+v\n", responseArchives))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list archives, got error: %s", err))
+		return
+	}
+
+	data.Archives = []archiveDataSourceData{}
+	for i := range responseArchives {
+		archive := &responseArchives[i]
+		if !data.match(filter, archive) {
+			continue
+		}
+		data.Archives = append(data.Archives, *newArchiveDataSourceData(archive))
+	}
+
+	sort.Slice(data.Archives, func(i, j int) bool {
+		return data.Archives[i].Name.Value < data.Archives[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccArchivesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccArchivesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_archives.test", "archives.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_archives.test", "archives.0.name", "kubeberth_archive.test", "name"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_archives.test", "archives.0.repository", "kubeberth_archive.test", "repository"),
+				),
+			},
+		},
+	})
+}
+
+const testAccArchivesDataSourceConfig = `
+resource "kubeberth_archive" "test" {
+  name       = "terraform-acc-archives"
+  repository = "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04-server-cloudimg-arm64.img"
+
+  labels = {
+    "terraform-acc" = "archives"
+  }
+}
+
+data "kubeberth_archives" "test" {
+  labels = {
+    "terraform-acc" = kubeberth_archive.test.labels["terraform-acc"]
+  }
+  state = kubeberth_archive.test.state
+}
+`
+
+func TestArchivesDataSourceMatch(t *testing.T) {
+	archive := &kubeberth.ResponseArchive{
+		Name:   "ubuntu",
+		State:  "Created",
+		Labels: map[string]string{"os": "ubuntu"},
+	}
+
+	tests := map[string]struct {
+		labels map[string]string
+		state  types.String
+		want   bool
+	}{
+		"no filters": {
+			state: types.String{Null: true},
+			want:  true,
+		},
+		"state matches": {
+			state: types.String{Value: "Created"},
+			want:  true,
+		},
+		"state differs": {
+			state: types.String{Value: "Importing"},
+			want:  false,
+		},
+		"labels and state match": {
+			labels: map[string]string{"os": "ubuntu"},
+			state:  types.String{Value: "Created"},
+			want:   true,
+		},
+		"labels differ": {
+			labels: map[string]string{"os": "debian"},
+			state:  types.String{Value: "Created"},
+			want:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := archivesDataSourceData{Labels: test.labels, State: test.state}
+
+			filter, err := newListFilter(types.String{Null: true}, test.labels)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := data.match(filter, archive); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kubeberth/kubeberth-go"
+)
+
+// isNotFound reports whether err is kubeberth's answer to a request for an
+// object that does not exist, as opposed to a connection or server error.
+func isNotFound(err error) bool {
+	var responseError *kubeberth.Error
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound
+}
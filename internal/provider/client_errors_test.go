@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"not found": {
+			err:  &kubeberth.Error{StatusCode: http.StatusNotFound, Message: "not found"},
+			want: true,
+		},
+		"wrapped not found": {
+			err:  fmt.Errorf("get archive: %w", &kubeberth.Error{StatusCode: http.StatusNotFound}),
+			want: true,
+		},
+		"server error": {
+			err:  &kubeberth.Error{StatusCode: http.StatusInternalServerError, Message: "internal error"},
+			want: false,
+		},
+		"forbidden": {
+			err:  &kubeberth.Error{StatusCode: http.StatusForbidden, Message: "forbidden"},
+			want: false,
+		},
+		"connection error": {
+			err:  errors.New("dial tcp 127.0.0.1:2022: connect: connection refused"),
+			want: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isNotFound(test.err); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -25,7 +25,7 @@ func (t cloudinitResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, dia
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
@@ -41,7 +41,7 @@ func (t cloudinitResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, dia
 				Type:                types.StringType,
 				Optional:            true,
 			},
-		},
+		})),
 	}, nil
 }
 
@@ -57,6 +57,11 @@ type cloudinitResourceData struct {
 	Name        types.String `tfsdk:"name"`
 	UserData    types.String `tfsdk:"user_data"`
 	NetworkData types.String `tfsdk:"network_data"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
 }
 
 type cloudinitResource struct {
@@ -78,10 +83,15 @@ func createNewCloudInit(data *cloudinitResourceData) *kubeberth.RequestCloudInit
 	return cloudinit
 }
 
+func (r cloudinitResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+}
+
 func (r cloudinitResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data cloudinitResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -97,13 +107,19 @@ func (r cloudinitResource) Create(ctx context.Context, req tfsdk.CreateResourceR
 	// }
 
 	newCloudInit := createNewCloudInit(&data)
-	createdCloudInit, err := r.provider.client.CreateCloudInit(ctx, newCloudInit)
+	newCloudInit.Labels, newCloudInit.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	newCloudInit.Namespace = data.Namespace.Value
+
+	createdCloudInit, err := r.provider.namespacedClient(data.Namespace).CreateCloudInit(ctx, newCloudInit)
 	tflog.Trace(ctx, fmt.Sprintf("cloudinit: %+v\n", createdCloudInit))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create cloudinit, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, createdCloudInit.Namespace)
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -136,13 +152,19 @@ func (r cloudinitResource) Read(ctx context.Context, req tfsdk.ReadResourceReque
 	//     return
 	// }
 
-	cloudinit, err := r.provider.client.GetCloudInit(ctx, data.Name.Value)
+	cloudinit, err := r.provider.namespacedClient(data.Namespace).GetCloudInit(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("cloudinit: %+v\n", cloudinit))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cloudinit, got error: %s", err))
 		return
 	}
 
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, cloudinit.Labels, cloudinit.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, cloudinit.Namespace)
+
+	data.UserData = types.String{Null: cloudinit.UserData == "", Value: cloudinit.UserData}
+	data.NetworkData = types.String{Null: cloudinit.NetworkData == "", Value: cloudinit.NetworkData}
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -168,7 +190,10 @@ func (r cloudinitResource) Update(ctx context.Context, req tfsdk.UpdateResourceR
 	// }
 
 	newCloudInit := createNewCloudInit(&data)
-	updatedCloudInit, err := r.provider.client.UpdateCloudInit(ctx, data.Name.Value, newCloudInit)
+	newCloudInit.Labels, newCloudInit.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	newCloudInit.Namespace = data.Namespace.Value
+
+	updatedCloudInit, err := r.provider.namespacedClient(data.Namespace).UpdateCloudInit(ctx, data.Name.Value, newCloudInit)
 	tflog.Trace(ctx, fmt.Sprintf("cloudinit: %+v\n", updatedCloudInit))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update cloudinit, got error: %s", err))
@@ -199,7 +224,7 @@ func (r cloudinitResource) Delete(ctx context.Context, req tfsdk.DeleteResourceR
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteCloudInit(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteCloudInit(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("cloudinit: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete cloudinit, got error: %s", err))
@@ -208,5 +233,5 @@ func (r cloudinitResource) Delete(ctx context.Context, req tfsdk.DeleteResourceR
 }
 
 func (r cloudinitResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
@@ -7,35 +7,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccCloudInitResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccCloudInitResourceConfig("Asia/Tokyo"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("scaffolding_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("kubeberth_cloudinit.test", "name", "terraform-acc-cloudinit"),
+					resource.TestCheckResourceAttr("kubeberth_cloudinit.test", "user_data", "#cloud-config\ntimezone: Asia/Tokyo\n"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "scaffolding_example.test",
+				ResourceName:      "kubeberth_cloudinit.test",
 				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_cloudinit.test", "name"),
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccCloudInitResourceConfig("UTC"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("kubeberth_cloudinit.test", "user_data", "#cloud-config\ntimezone: UTC\n"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -43,10 +39,14 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func testAccCloudInitResourceConfig(timezone string) string {
 	return fmt.Sprintf(`
-resource "scaffolding_example" "test" {
-  configurable_attribute = %[1]q
+resource "kubeberth_cloudinit" "test" {
+  name      = "terraform-acc-cloudinit"
+  user_data = <<EOF
+#cloud-config
+timezone: %[1]s
+EOF
 }
-`, configurableAttribute)
+`, timezone)
 }
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = cloudinitsDataSourceType{}
+var _ tfsdk.DataSource = cloudinitsDataSource{}
+
+type cloudinitsDataSourceType struct{}
+
+func (t cloudinitsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["cloudinits"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching cloudinits, sorted by name.",
+		Computed:            true,
+		Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+			"name": {
+				MarkdownDescription: "name",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"user_data": {
+				MarkdownDescription: "user_data",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"network_data": {
+				MarkdownDescription: "network_data",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+		}, tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth cloudinits.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (t cloudinitsDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return cloudinitsDataSource{
+		provider: provider,
+	}, diags
+}
+
+type cloudinitDataSourceData struct {
+	Name        types.String `tfsdk:"name"`
+	UserData    types.String `tfsdk:"user_data"`
+	NetworkData types.String `tfsdk:"network_data"`
+}
+
+func newCloudInitDataSourceData(cloudinit *kubeberth.ResponseCloudInit) *cloudinitDataSourceData {
+	return &cloudinitDataSourceData{
+		Name:        types.String{Value: cloudinit.Name},
+		UserData:    types.String{Value: cloudinit.UserData},
+		NetworkData: types.String{Value: cloudinit.NetworkData},
+	}
+}
+
+type cloudinitsDataSourceData struct {
+	NameRegex  types.String              `tfsdk:"name_regex"`
+	Labels     map[string]string         `tfsdk:"labels"`
+	CloudInits []cloudinitDataSourceData `tfsdk:"cloudinits"`
+}
+
+type cloudinitsDataSource struct {
+	provider provider
+}
+
+func (d cloudinitsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data cloudinitsDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseCloudInits, err := d.provider.client.GetAllCloudInits(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("cloudinits: %+v\n", responseCloudInits))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list cloudinits, got error: %s", err))
+		return
+	}
+
+	data.CloudInits = []cloudinitDataSourceData{}
+	for i := range responseCloudInits {
+		cloudinit := &responseCloudInits[i]
+		if !filter.match(cloudinit.Name, cloudinit.Labels) {
+			continue
+		}
+		data.CloudInits = append(data.CloudInits, *newCloudInitDataSourceData(cloudinit))
+	}
+
+	sort.Slice(data.CloudInits, func(i, j int) bool {
+		return data.CloudInits[i].Name.Value < data.CloudInits[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
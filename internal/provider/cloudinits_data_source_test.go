@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCloudInitsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCloudInitsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_cloudinits.test", "cloudinits.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_cloudinits.test", "cloudinits.0.name", "kubeberth_cloudinit.test", "name"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_cloudinits.test", "cloudinits.0.user_data", "kubeberth_cloudinit.test", "user_data"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCloudInitsDataSourceConfig = `
+resource "kubeberth_cloudinit" "test" {
+  name      = "terraform-acc-cloudinits"
+  user_data = <<EOF
+#cloud-config
+timezone: UTC
+EOF
+}
+
+data "kubeberth_cloudinits" "test" {
+  name_regex = "^${kubeberth_cloudinit.test.name}$"
+}
+`
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = clusterInfoDataSourceType{}
+var _ tfsdk.DataSource = clusterInfoDataSource{}
+
+type clusterInfoDataSourceType struct{}
+
+func (t clusterInfoDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reports versions and capabilities of the kubeberth cluster the provider is connected to.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"api_version": {
+				MarkdownDescription: "Version of the kubeberth API server.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"operator_version": {
+				MarkdownDescription: "Version of the kubeberth operator.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"nodes": {
+				MarkdownDescription: "Schedulable nodes that can be used as `hosting` for a server, sorted by name.",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"storage_classes": {
+				MarkdownDescription: "Storage classes available for disks, sorted by name.",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (t clusterInfoDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return clusterInfoDataSource{
+		provider: provider,
+	}, diags
+}
+
+type clusterInfoDataSourceData struct {
+	APIVersion      types.String `tfsdk:"api_version"`
+	OperatorVersion types.String `tfsdk:"operator_version"`
+	Nodes           []string     `tfsdk:"nodes"`
+	StorageClasses  []string     `tfsdk:"storage_classes"`
+}
+
+type clusterInfoDataSource struct {
+	provider provider
+}
+
+func (d clusterInfoDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	info, err := d.provider.client.GetClusterInfo(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("cluster info: %+v\n", info))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster info, got error: %s", err))
+		return
+	}
+
+	data := clusterInfoDataSourceData{
+		APIVersion:      types.String{Value: info.APIVersion},
+		OperatorVersion: types.String{Value: info.OperatorVersion},
+		Nodes:           append([]string{}, info.Nodes...),
+		StorageClasses:  append([]string{}, info.StorageClasses...),
+	}
+	sort.Strings(data.Nodes)
+	sort.Strings(data.StorageClasses)
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
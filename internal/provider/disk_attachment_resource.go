@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = diskAttachmentResourceType{}
+var _ tfsdk.Resource = diskAttachmentResource{}
+var _ tfsdk.ResourceWithImportState = diskAttachmentResource{}
+
+type diskAttachmentResourceType struct{}
+
+func (t diskAttachmentResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches a disk to a server managed elsewhere. Set `exclusive_disks = false` on the `kubeberth_server` so that it keeps the attachment.",
+
+		Attributes: addNamespaceAttribute(map[string]tfsdk.Attribute{
+			"server": {
+				MarkdownDescription: "Name of the server.",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"disk": {
+				MarkdownDescription: "Name of the disk.",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+		}),
+	}, nil
+}
+
+func (t diskAttachmentResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return diskAttachmentResource{
+		provider: provider,
+	}, diags
+}
+
+type diskAttachmentResourceData struct {
+	Server    types.String `tfsdk:"server"`
+	Disk      types.String `tfsdk:"disk"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+type diskAttachmentResource struct {
+	provider provider
+}
+
+// updateServerDisks applies change to the disk list of a server while holding
+// the server lock.
+func (r diskAttachmentResource) updateServerDisks(ctx context.Context, namespace types.String, name string, change func([]kubeberth.AttachedDisk) []kubeberth.AttachedDisk) error {
+	unlock := r.provider.lockServer(namespace, name)
+	defer unlock()
+
+	return modifyServer(ctx, r.provider.namespacedClient(namespace), name, func(server *kubeberth.RequestServer) bool {
+		server.Disks = change(server.Disks)
+		return true
+	})
+}
+
+func hasAttachedDisk(disks []kubeberth.AttachedDisk, name string) bool {
+	for _, disk := range disks {
+		if disk.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r diskAttachmentResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+}
+
+func (r diskAttachmentResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data diskAttachmentResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	err := r.updateServerDisks(ctx, data.Namespace, data.Server.Value, func(disks []kubeberth.AttachedDisk) []kubeberth.AttachedDisk {
+		if hasAttachedDisk(disks, data.Disk.Value) {
+			return disks
+		}
+		return append(disks, kubeberth.AttachedDisk{Name: data.Disk.Value})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach disk %q to server %q, got error: %s", data.Disk.Value, data.Server.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r diskAttachmentResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data diskAttachmentResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	responseServer, err := r.provider.namespacedClient(data.Namespace).GetServer(ctx, data.Server.Value)
+	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+		return
+	}
+
+	if !hasAttachedDisk(responseServer.Disks, data.Disk.Value) {
+		tflog.Warn(ctx, fmt.Sprintf("disk %q is no longer attached to server %q, removing from state", data.Disk.Value, data.Server.Value))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Namespace = refreshNamespace(data.Namespace, responseServer.Namespace)
+
+	tflog.Trace(ctx, "read a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r diskAttachmentResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data diskAttachmentResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r diskAttachmentResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data diskAttachmentResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateServerDisks(ctx, data.Namespace, data.Server.Value, func(disks []kubeberth.AttachedDisk) []kubeberth.AttachedDisk {
+		kept := []kubeberth.AttachedDisk{}
+		for _, disk := range disks {
+			if disk.Name != data.Disk.Value {
+				kept = append(kept, disk)
+			}
+		}
+		return kept
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach disk %q from server %q, got error: %s", data.Disk.Value, data.Server.Value, err))
+		return
+	}
+}
+
+func (r diskAttachmentResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) == 3 {
+		diags := resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("namespace"), parts[0])
+		resp.Diagnostics.Append(diags...)
+		parts = parts[1:]
+	}
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: namespace/server/disk or server/disk. Got: %q", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("server"), parts[0])
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("disk"), parts[1])
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDiskAttachmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDiskAttachmentResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_disk_attachment.test", "server", "terraform-acc-attachment"),
+					resource.TestCheckResourceAttr("kubeberth_disk_attachment.test", "disk", "terraform-acc-attachment-data"),
+					resource.TestCheckResourceAttr("data.kubeberth_disk.test", "attached_to.0", "terraform-acc-attachment"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "kubeberth_disk_attachment.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_disk_attachment.test", "server", "disk"),
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccDiskAttachmentResourceConfig = `
+resource "kubeberth_server" "test" {
+  name            = "terraform-acc-attachment"
+  running         = false
+  cpu             = 1
+  memory          = "1Gi"
+  hostname        = "terraform-acc-attachment"
+  exclusive_disks = false
+}
+
+resource "kubeberth_disk" "test" {
+  name = "terraform-acc-attachment-data"
+  size = "1Gi"
+}
+
+resource "kubeberth_disk_attachment" "test" {
+  server = kubeberth_server.test.name
+  disk   = kubeberth_disk.test.name
+}
+
+data "kubeberth_disk" "test" {
+  name = kubeberth_disk_attachment.test.disk
+}
+`
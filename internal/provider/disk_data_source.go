@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = diskDataSourceType{}
+var _ tfsdk.DataSource = diskDataSource{}
+
+type diskDataSourceType struct{}
+
+func (t diskDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := diskAttributes()
+	attributes["name"] = tfsdk.Attribute{
+		MarkdownDescription: "name",
+		Type:                types.StringType,
+		Required:            true,
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing kubeberth disk by name.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (t diskDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return diskDataSource{
+		provider: provider,
+	}, diags
+}
+
+type diskDataSource struct {
+	provider provider
+}
+
+func (d diskDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var config diskDataSourceData
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disk, err := d.provider.client.GetDisk(ctx, config.Name.Value)
+	tflog.Trace(ctx, fmt.Sprintf("disk: %+v\n", disk))
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("name"),
+			"Disk Not Found",
+			fmt.Sprintf("Unable to read disk %q, got error: %s\n\nCheck that the disk exists and that its name is spelled correctly.", config.Name.Value, err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read disk %q, got error: %s", config.Name.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, newDiskDataSourceData(disk))
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDiskDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDiskDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_disk.test", "name", "terraform-acc-disk"),
+					resource.TestCheckResourceAttr("data.kubeberth_disk.test", "size", "1Gi"),
+					resource.TestCheckResourceAttr("data.kubeberth_disk.test", "attached_to.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDiskDataSourceConfig = `
+resource "kubeberth_disk" "test" {
+  name = "terraform-acc-disk"
+  size = "1Gi"
+}
+
+data "kubeberth_disk" "test" {
+  name = kubeberth_disk.test.name
+}
+`
@@ -25,7 +25,7 @@ func (t diskResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
@@ -50,7 +50,7 @@ func (t diskResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 					},
 				}),
 			},
-		},
+		})),
 	}, nil
 }
 
@@ -67,10 +67,36 @@ type sourceData struct {
 	Disk    types.String `tfsdk:"disk"`
 }
 
+// newSourceData converts the source of a disk reported by kubeberth, nil if
+// the disk was created empty.
+func newSourceData(source *kubeberth.AttachedSource) *sourceData {
+	if source == nil || (source.Archive == nil && source.Disk == nil) {
+		return nil
+	}
+
+	data := &sourceData{
+		Archive: types.String{Null: true},
+		Disk:    types.String{Null: true},
+	}
+	if source.Archive != nil {
+		data.Archive = types.String{Value: source.Archive.Name}
+	}
+	if source.Disk != nil {
+		data.Disk = types.String{Value: source.Disk.Name}
+	}
+
+	return data
+}
+
 type diskResourceData struct {
 	Name   types.String `tfsdk:"name"`
 	Size   types.String `tfsdk:"size"`
 	Source *sourceData  `tfsdk:"source"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
 }
 
 type diskResource struct {
@@ -100,10 +126,15 @@ func newRequestDisk(data *diskResourceData) *kubeberth.RequestDisk {
 	return requestDisk
 }
 
+func (r diskResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+}
+
 func (r diskResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data diskResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -119,13 +150,19 @@ func (r diskResource) Create(ctx context.Context, req tfsdk.CreateResourceReques
 	// }
 
 	requestDisk := newRequestDisk(&data)
-	responseDisk, err := r.provider.client.CreateDisk(ctx, requestDisk)
+	requestDisk.Labels, requestDisk.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	requestDisk.Namespace = data.Namespace.Value
+
+	responseDisk, err := r.provider.namespacedClient(data.Namespace).CreateDisk(ctx, requestDisk)
 	tflog.Trace(ctx, fmt.Sprintf("disk: %+v\n", responseDisk))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create disk, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, responseDisk.Namespace)
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -158,13 +195,21 @@ func (r diskResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, r
 	//     return
 	// }
 
-	responseDisk, err := r.provider.client.GetDisk(ctx, data.Name.Value)
+	responseDisk, err := r.provider.namespacedClient(data.Namespace).GetDisk(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("disk: %+v\n", responseDisk))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read disk, got error: %s", err))
 		return
 	}
 
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, responseDisk.Labels, responseDisk.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, responseDisk.Namespace)
+
+	if !quantitiesEqual(data.Size.Value, responseDisk.Size) {
+		data.Size = types.String{Value: responseDisk.Size}
+	}
+	data.Source = newSourceData(responseDisk.Source)
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -190,7 +235,10 @@ func (r diskResource) Update(ctx context.Context, req tfsdk.UpdateResourceReques
 	// }
 
 	requestDisk := newRequestDisk(&data)
-	responseDisk, err := r.provider.client.UpdateDisk(ctx, data.Name.Value, requestDisk)
+	requestDisk.Labels, requestDisk.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	requestDisk.Namespace = data.Namespace.Value
+
+	responseDisk, err := r.provider.namespacedClient(data.Namespace).UpdateDisk(ctx, data.Name.Value, requestDisk)
 	tflog.Trace(ctx, fmt.Sprintf("disk: %+v\n", responseDisk))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update disk, got error: %s", err))
@@ -221,7 +269,7 @@ func (r diskResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReques
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteDisk(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteDisk(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("disk: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete disk, got error: %s", err))
@@ -230,5 +278,5 @@ func (r diskResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReques
 }
 
 func (r diskResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
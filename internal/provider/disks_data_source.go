@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeberth/kubeberth-go"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = disksDataSourceType{}
+var _ tfsdk.DataSource = disksDataSource{}
+
+type disksDataSourceType struct{}
+
+func (t disksDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["state"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return disks in this state.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["min_size"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return disks at least this large, e.g. `100Gi`.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["max_size"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return disks at most this large, e.g. `100Gi`.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["disks"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching disks, sorted by name.",
+		Computed:            true,
+		Attributes:          tfsdk.ListNestedAttributes(diskAttributes(), tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth disks.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+// diskAttributes returns the computed attributes describing a disk.
+func diskAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name": {
+			MarkdownDescription: "name",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"size": {
+			MarkdownDescription: "size",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"state": {
+			MarkdownDescription: "state",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"source": {
+			MarkdownDescription: "source",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"archive": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"disk": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}),
+		},
+		"attached_to": {
+			MarkdownDescription: "Names of the servers the disk is attached to.",
+			Type:                types.ListType{ElemType: types.StringType},
+			Computed:            true,
+		},
+	}
+}
+
+func (t disksDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return disksDataSource{
+		provider: provider,
+	}, diags
+}
+
+type diskDataSourceData struct {
+	Name       types.String   `tfsdk:"name"`
+	Size       types.String   `tfsdk:"size"`
+	State      types.String   `tfsdk:"state"`
+	Source     *sourceData    `tfsdk:"source"`
+	AttachedTo []types.String `tfsdk:"attached_to"`
+}
+
+func newDiskDataSourceData(disk *kubeberth.ResponseDisk) *diskDataSourceData {
+	data := &diskDataSourceData{
+		Name:       types.String{Value: disk.Name},
+		Size:       types.String{Value: disk.Size},
+		State:      types.String{Value: disk.State},
+		AttachedTo: []types.String{},
+	}
+
+	if disk.Source != nil {
+		data.Source = &sourceData{
+			Archive: types.String{Null: true},
+			Disk:    types.String{Null: true},
+		}
+		if disk.Source.Archive != nil {
+			data.Source.Archive = types.String{Value: disk.Source.Archive.Name}
+		}
+		if disk.Source.Disk != nil {
+			data.Source.Disk = types.String{Value: disk.Source.Disk.Name}
+		}
+	}
+
+	for _, server := range disk.AttachedTo {
+		data.AttachedTo = append(data.AttachedTo, types.String{Value: server.Name})
+	}
+
+	return data
+}
+
+type disksDataSourceData struct {
+	NameRegex types.String         `tfsdk:"name_regex"`
+	Labels    map[string]string    `tfsdk:"labels"`
+	State     types.String         `tfsdk:"state"`
+	MinSize   types.String         `tfsdk:"min_size"`
+	MaxSize   types.String         `tfsdk:"max_size"`
+	Disks     []diskDataSourceData `tfsdk:"disks"`
+}
+
+// match reports whether disk passes the filters of the data source, given
+// the parsed min_size and max_size.
+func (data *disksDataSourceData) match(filter *listFilter, minSize *resource.Quantity, maxSize *resource.Quantity, disk *kubeberth.ResponseDisk) (bool, error) {
+	if !filter.match(disk.Name, disk.Labels) {
+		return false, nil
+	}
+	if !data.State.Null && disk.State != data.State.Value {
+		return false, nil
+	}
+
+	if minSize == nil && maxSize == nil {
+		return true, nil
+	}
+
+	size, err := resource.ParseQuantity(disk.Size)
+	if err != nil {
+		return false, fmt.Errorf("invalid size %q: %w", disk.Size, err)
+	}
+	if minSize != nil && size.Cmp(*minSize) < 0 {
+		return false, nil
+	}
+	if maxSize != nil && size.Cmp(*maxSize) > 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+type disksDataSource struct {
+	provider provider
+}
+
+func parseOptionalQuantity(value types.String) (*resource.Quantity, error) {
+	if value.Null || value.Value == "" {
+		return nil, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity %q: %w", value.Value, err)
+	}
+
+	return &quantity, nil
+}
+
+func (d disksDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data disksDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	minSize, err := parseOptionalQuantity(data.MinSize)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("min_size"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	maxSize, err := parseOptionalQuantity(data.MaxSize)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("max_size"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseDisks, err := d.provider.client.GetAllDisks(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("disks: %+v\n", responseDisks))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list disks, got error: %s", err))
+		return
+	}
+
+	data.Disks = []diskDataSourceData{}
+	for i := range responseDisks {
+		disk := &responseDisks[i]
+		match, err := data.match(filter, minSize, maxSize, disk)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read disk %q, got error: %s", disk.Name, err))
+			return
+		}
+		if !match {
+			continue
+		}
+
+		data.Disks = append(data.Disks, *newDiskDataSourceData(disk))
+	}
+
+	sort.Slice(data.Disks, func(i, j int) bool {
+		return data.Disks[i].Name.Value < data.Disks[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDisksDataSource(t *testing.T) {
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			// Read testing
+			{
+				Config: testAccDisksDataSourceConfig,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("data.kubeberth_disks.test", "disks.#", "1"),
+					tfresource.TestCheckResourceAttrPair("data.kubeberth_disks.test", "disks.0.name", "kubeberth_disk.test", "name"),
+					tfresource.TestCheckResourceAttr("data.kubeberth_disks.test", "disks.0.size", "1Gi"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDisksDataSourceConfig = `
+resource "kubeberth_disk" "test" {
+  name = "terraform-acc-disks"
+  size = "1Gi"
+}
+
+data "kubeberth_disks" "test" {
+  name_regex = "^${kubeberth_disk.test.name}$"
+  min_size   = "512Mi"
+  max_size   = "1Gi"
+}
+`
+
+func TestDisksDataSourceMatch(t *testing.T) {
+	disk := &kubeberth.ResponseDisk{
+		Name:  "data-1",
+		State: "Created",
+		Size:  "10Gi",
+	}
+
+	quantity := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+
+	tests := map[string]struct {
+		state   types.String
+		minSize *resource.Quantity
+		maxSize *resource.Quantity
+		disk    *kubeberth.ResponseDisk
+		want    bool
+		wantErr bool
+	}{
+		"no filters": {
+			state: types.String{Null: true},
+			disk:  disk,
+			want:  true,
+		},
+		"state matches": {
+			state: types.String{Value: "Created"},
+			disk:  disk,
+			want:  true,
+		},
+		"state differs": {
+			state: types.String{Value: "Failed"},
+			disk:  disk,
+			want:  false,
+		},
+		"within size range": {
+			state:   types.String{Null: true},
+			minSize: quantity("10Gi"),
+			maxSize: quantity("10Gi"),
+			disk:    disk,
+			want:    true,
+		},
+		"equal size in other units": {
+			state:   types.String{Null: true},
+			minSize: quantity("10240Mi"),
+			disk:    disk,
+			want:    true,
+		},
+		"below min size": {
+			state:   types.String{Null: true},
+			minSize: quantity("20Gi"),
+			disk:    disk,
+			want:    false,
+		},
+		"above max size": {
+			state:   types.String{Null: true},
+			maxSize: quantity("5Gi"),
+			disk:    disk,
+			want:    false,
+		},
+		"invalid size": {
+			state:   types.String{Null: true},
+			minSize: quantity("1Gi"),
+			disk:    &kubeberth.ResponseDisk{Name: "data-2", Size: "large"},
+			wantErr: true,
+		},
+		"invalid size without size filters": {
+			state: types.String{Null: true},
+			disk:  &kubeberth.ResponseDisk{Name: "data-2", Size: "large"},
+			want:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := disksDataSourceData{State: test.state}
+
+			got, err := data.match(testListFilter(t), test.minSize, test.maxSize, test.disk)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %t", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
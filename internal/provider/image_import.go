@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Values of ResponseArchive.State and ResponseISOImage.State reported by
+	// kubeberth.
+	imageStateCreated = "Created"
+	imageStateFailed  = "Failed"
+
+	defaultImportTimeout = time.Hour
+)
+
+// importPollInterval is how often waitForImport asks kubeberth for the
+// progress of an import.
+var importPollInterval = 5 * time.Second
+
+var checksumRegexp = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// addImportAttributes adds the attributes shared by the resources importing
+// an image from a repository: archives and isoimages.
+func addImportAttributes(attributes map[string]tfsdk.Attribute) map[string]tfsdk.Attribute {
+	attributes["checksum"] = tfsdk.Attribute{
+		MarkdownDescription: "Checksum of the imported image, `sha256:` followed by the hex digest. If set, the import fails unless the image matches it; changing it forces a new resource.",
+		Type:                types.StringType,
+		Optional:            true,
+		Computed:            true,
+		Validators: []tfsdk.AttributeValidator{
+			stringMatches(checksumRegexp, "value must be `sha256:` followed by 64 lowercase hex digits"),
+		},
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			tfsdk.UseStateForUnknown(),
+			tfsdk.RequiresReplace(),
+		},
+	}
+	attributes["state"] = tfsdk.Attribute{
+		MarkdownDescription: "State of the import reported by kubeberth, e.g. `Created`.",
+		Type:                types.StringType,
+		Computed:            true,
+	}
+	attributes["repository_credentials"] = tfsdk.Attribute{
+		MarkdownDescription: "Credentials to pull from `repository`: `username` and `password`, a bearer `token`, or the name of a kubeberth `secret` holding them. For `s3://` repositories, `username` and `password` are the access key ID and secret access key.",
+		Optional:            true,
+		Sensitive:           true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"username": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"password": {
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"token": {
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"secret": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+		}),
+	}
+	attributes["import_timeout"] = tfsdk.Attribute{
+		MarkdownDescription: "How long to wait for the image to be imported. Defaults to `1h`.",
+		Type:                types.StringType,
+		Optional:            true,
+		Validators: []tfsdk.AttributeValidator{
+			durationString(),
+		},
+	}
+
+	return attributes
+}
+
+type repositoryCredentialsData struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+	Secret   types.String `tfsdk:"secret"`
+}
+
+func newRepositoryCredentials(data *repositoryCredentialsData) *kubeberth.RepositoryCredentials {
+	if data == nil {
+		return nil
+	}
+
+	return &kubeberth.RepositoryCredentials{
+		Username:  data.Username.Value,
+		Password:  data.Password.Value,
+		Token:     data.Token.Value,
+		SecretRef: data.Secret.Value,
+	}
+}
+
+// validateRepositoryCredentials checks that repository_credentials sets
+// exactly one kind of credentials and is only used with repository.
+func validateRepositoryCredentials(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	path := tftypes.NewAttributePath().WithAttributeName("repository_credentials")
+
+	var object types.Object
+	var repository types.String
+	diags := config.GetAttribute(ctx, path, &object)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("repository"), &repository)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || object.Null || object.Unknown {
+		return
+	}
+
+	if repository.Null {
+		diagnostics.AddAttributeError(path, "Invalid Attribute Combination",
+			"repository_credentials can only be set together with repository.")
+		return
+	}
+
+	var credentials repositoryCredentialsData
+	diags = object.As(ctx, &credentials, types.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if credentials.Username.Null != credentials.Password.Null {
+		diagnostics.AddAttributeError(path, "Invalid Attribute Combination",
+			"username and password must be set together.")
+		return
+	}
+
+	kinds := 0
+	for _, set := range []bool{!credentials.Username.Null, !credentials.Token.Null, !credentials.Secret.Null} {
+		if set {
+			kinds++
+		}
+	}
+
+	if kinds != 1 {
+		diagnostics.AddAttributeError(path, "Invalid Attribute Combination",
+			"Exactly one of username and password, token or secret must be set.")
+	}
+}
+
+// importStatus is the progress of an import reported by kubeberth.
+type importStatus struct {
+	State    string
+	Progress string
+	Message  string
+	Size     string
+	Checksum string
+}
+
+func importTimeout(value types.String) time.Duration {
+	if value.Null || value.Unknown {
+		return defaultImportTimeout
+	}
+
+	// Already checked by the attribute validator.
+	timeout, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return defaultImportTimeout
+	}
+
+	return timeout
+}
+
+// waitForImport polls an archive or isoimage through get until its import
+// completes, fails or the timeout expires, logging the download progress.
+func waitForImport(ctx context.Context, kind string, name string, timeout time.Duration, get func(ctx context.Context) (*importStatus, error)) (*importStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(importPollInterval)
+	defer ticker.Stop()
+
+	progress := ""
+	for {
+		status, err := get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status.State {
+		case imageStateCreated:
+			tflog.Info(ctx, fmt.Sprintf("imported %s %q", kind, name))
+			return status, nil
+		case imageStateFailed:
+			return status, fmt.Errorf("import of %s %q failed: %s", kind, name, status.Message)
+		}
+		if status.Progress != progress {
+			progress = status.Progress
+			tflog.Info(ctx, fmt.Sprintf("importing %s %q: %s, currently %s", kind, name, progress, status.State))
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("timed out after %s waiting for import of %s %q, currently %s at %s", timeout, kind, name, status.State, status.Progress)
+		case <-ticker.C:
+		}
+	}
+}
+
+// verifyChecksum checks the checksum of an imported image against the
+// configured one, if any.
+func verifyChecksum(kind string, name string, want types.String, got string) error {
+	if want.Null || want.Unknown {
+		return nil
+	}
+
+	if got == "" {
+		return fmt.Errorf("kubeberth did not report a checksum for %s %q, expected %s", kind, name, want.Value)
+	}
+
+	if !strings.EqualFold(want.Value, got) {
+		return fmt.Errorf("checksum mismatch for %s %q: expected %s, got %s", kind, name, want.Value, got)
+	}
+
+	return nil
+}
+
+// refreshChecksum returns the checksum kubeberth reports for an image in the
+// lowercase form of the checksum attribute, keeping checksum if it denotes
+// the same digest. An unknown checksum becomes null if kubeberth reports none.
+func refreshChecksum(checksum types.String, current string) types.String {
+	if current == "" {
+		if checksum.Unknown {
+			return types.String{Null: true}
+		}
+		return checksum
+	}
+
+	if !checksum.Null && !checksum.Unknown && strings.EqualFold(checksum.Value, current) {
+		return checksum
+	}
+
+	return types.String{Value: strings.ToLower(current)}
+}
+
+// importPending reports whether Update has to wait for an import: kubeberth
+// imports the image again when repository or source_file_hash changes, and
+// an earlier import may still be in progress. A failed import is only
+// retried by changing the image, so other updates leave it Failed.
+func importPending(priorState types.String, repository, priorRepository types.String, sourceFileHash, priorSourceFileHash types.String) bool {
+	if !repository.Equal(priorRepository) || !sourceFileHash.Equal(priorSourceFileHash) {
+		return true
+	}
+
+	return priorState.Value != imageStateCreated && priorState.Value != imageStateFailed
+}
+
+// modifyPlanImport marks the computed results of an import as unknown when
+// the repository changes, as kubeberth imports the image again.
+func modifyPlanImport(ctx context.Context, computed []string, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName("repository")
+
+	var planned, current types.String
+	diags := req.Plan.GetAttribute(ctx, path, &planned)
+	diags.Append(req.State.GetAttribute(ctx, path, &current)...)
+	resp.Diagnostics.Append(diags...)
+
+	if diags.HasError() || planned.Equal(current) {
+		return
+	}
+
+	planUnknownUnlessConfigured(ctx, computed, req, resp)
+}
+
+// planUnknownUnlessConfigured marks the named attributes as unknown in the
+// plan unless they are configured.
+func planUnknownUnlessConfigured(ctx context.Context, names []string, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	for _, name := range names {
+		path := tftypes.NewAttributePath().WithAttributeName(name)
+
+		var value types.String
+		diags := req.Config.GetAttribute(ctx, path, &value)
+		resp.Diagnostics.Append(diags...)
+
+		if diags.HasError() || !value.Null {
+			continue
+		}
+
+		diags = resp.Plan.SetAttribute(ctx, path, types.String{Unknown: true})
+		resp.Diagnostics.Append(diags...)
+	}
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testChecksum = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestImportTimeout(t *testing.T) {
+	tests := map[string]struct {
+		value types.String
+		want  time.Duration
+	}{
+		"null": {
+			value: types.String{Null: true},
+			want:  defaultImportTimeout,
+		},
+		"unknown": {
+			value: types.String{Unknown: true},
+			want:  defaultImportTimeout,
+		},
+		"set": {
+			value: types.String{Value: "90m"},
+			want:  90 * time.Minute,
+		},
+		"invalid": {
+			value: types.String{Value: "later"},
+			want:  defaultImportTimeout,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := importTimeout(test.value); got != test.want {
+				t.Errorf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWaitForImport(t *testing.T) {
+	defer func(interval time.Duration) { importPollInterval = interval }(importPollInterval)
+	importPollInterval = time.Millisecond
+
+	errUnavailable := errors.New("unavailable")
+
+	tests := map[string]struct {
+		statuses  []importStatus
+		err       error
+		timeout   time.Duration
+		wantState string
+		wantError string
+	}{
+		"created": {
+			statuses:  []importStatus{{State: "Importing", Progress: "10%"}, {State: "Importing", Progress: "50%"}, {State: imageStateCreated, Checksum: testChecksum}},
+			timeout:   time.Minute,
+			wantState: imageStateCreated,
+		},
+		"failed": {
+			statuses:  []importStatus{{State: "Importing"}, {State: imageStateFailed, Message: "404 Not Found"}},
+			timeout:   time.Minute,
+			wantState: imageStateFailed,
+			wantError: "404 Not Found",
+		},
+		"timed out": {
+			statuses:  []importStatus{{State: "Importing", Progress: "10%"}},
+			timeout:   20 * time.Millisecond,
+			wantState: "Importing",
+			wantError: "timed out",
+		},
+		"client error": {
+			err:       errUnavailable,
+			timeout:   time.Minute,
+			wantError: "unavailable",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			status, err := waitForImport(context.Background(), "archive", "ubuntu", test.timeout, func(ctx context.Context) (*importStatus, error) {
+				if test.err != nil {
+					return nil, test.err
+				}
+
+				// Keep reporting the last status once all were returned.
+				status := test.statuses[len(test.statuses)-1]
+				if calls < len(test.statuses) {
+					status = test.statuses[calls]
+				}
+				calls++
+
+				return &status, nil
+			})
+
+			if test.wantError == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.wantError != "" && (err == nil || !strings.Contains(err.Error(), test.wantError)) {
+				t.Fatalf("expected an error containing %q, got %v", test.wantError, err)
+			}
+
+			state := ""
+			if status != nil {
+				state = status.State
+			}
+			if state != test.wantState {
+				t.Errorf("expected state %q, got %q", test.wantState, state)
+			}
+			if test.wantState == imageStateCreated && calls != len(test.statuses) {
+				t.Errorf("expected %d polls, got %d", len(test.statuses), calls)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	tests := map[string]struct {
+		want      types.String
+		got       string
+		wantError bool
+	}{
+		"not configured": {
+			want: types.String{Null: true},
+			got:  testChecksum,
+		},
+		"unknown": {
+			want: types.String{Unknown: true},
+		},
+		"matches": {
+			want: types.String{Value: testChecksum},
+			got:  testChecksum,
+		},
+		"matches in upper case": {
+			want: types.String{Value: testChecksum},
+			got:  strings.ToUpper(testChecksum),
+		},
+		"mismatch": {
+			want:      types.String{Value: testChecksum},
+			got:       "sha256:" + strings.Repeat("0", 64),
+			wantError: true,
+		},
+		"not reported": {
+			want:      types.String{Value: testChecksum},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := verifyChecksum("archive", "ubuntu", test.want, test.got)
+			if got := err != nil; got != test.wantError {
+				t.Errorf("expected error %t, got %v", test.wantError, err)
+			}
+		})
+	}
+}
+
+func TestRefreshChecksum(t *testing.T) {
+	tests := map[string]struct {
+		checksum types.String
+		current  string
+		want     types.String
+	}{
+		"not reported": {
+			checksum: types.String{Value: testChecksum},
+			want:     types.String{Value: testChecksum},
+		},
+		"unknown and not reported": {
+			checksum: types.String{Unknown: true},
+			want:     types.String{Null: true},
+		},
+		"unknown": {
+			checksum: types.String{Unknown: true},
+			current:  strings.ToUpper(testChecksum),
+			want:     types.String{Value: testChecksum},
+		},
+		"same digest in upper case": {
+			checksum: types.String{Value: testChecksum},
+			current:  strings.ToUpper(testChecksum),
+			want:     types.String{Value: testChecksum},
+		},
+		"changed": {
+			checksum: types.String{Value: "sha256:" + strings.Repeat("0", 64)},
+			current:  strings.ToUpper(testChecksum),
+			want:     types.String{Value: testChecksum},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := refreshChecksum(test.checksum, test.current); !got.Equal(test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestImportPending(t *testing.T) {
+	created := types.String{Value: imageStateCreated}
+	repository := types.String{Value: "https://example.com/ubuntu.img"}
+	noHash := types.String{Null: true}
+
+	tests := map[string]struct {
+		priorState      types.String
+		repository      types.String
+		priorRepository types.String
+		hash            types.String
+		priorHash       types.String
+		want            bool
+	}{
+		"unchanged": {
+			priorState:      created,
+			repository:      repository,
+			priorRepository: repository,
+			hash:            noHash,
+			priorHash:       noHash,
+			want:            false,
+		},
+		"repository changed": {
+			priorState:      created,
+			repository:      types.String{Value: "https://example.com/debian.img"},
+			priorRepository: repository,
+			hash:            noHash,
+			priorHash:       noHash,
+			want:            true,
+		},
+		"source file changed": {
+			priorState:      created,
+			repository:      types.String{Null: true},
+			priorRepository: types.String{Null: true},
+			hash:            types.String{Value: testChecksum},
+			priorHash:       types.String{Value: "sha256:" + strings.Repeat("0", 64)},
+			want:            true,
+		},
+		"label-only update after a failed import": {
+			priorState:      types.String{Value: imageStateFailed},
+			repository:      repository,
+			priorRepository: repository,
+			hash:            noHash,
+			priorHash:       noHash,
+			want:            false,
+		},
+		"repository changed after a failed import": {
+			priorState:      types.String{Value: imageStateFailed},
+			repository:      types.String{Value: "https://example.com/debian.img"},
+			priorRepository: repository,
+			hash:            noHash,
+			priorHash:       noHash,
+			want:            true,
+		},
+		"previous import in progress": {
+			priorState:      types.String{Value: "ImportInProgress"},
+			repository:      repository,
+			priorRepository: repository,
+			hash:            noHash,
+			priorHash:       noHash,
+			want:            true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := importPending(test.priorState, test.repository, test.priorRepository, test.hash, test.priorHash); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
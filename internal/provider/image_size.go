@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// imageSizeHeadroom is the space, in percent of the image, added for the
+	// filesystem holding it.
+	imageSizeHeadroom = 10
+	imageSizeUnit     = 1 << 30
+
+	headRequestTimeout = 30 * time.Second
+)
+
+// imageSize returns a size large enough to import an image of length bytes:
+// the length plus headroom, rounded up to whole Gi.
+func imageSize(length int64) string {
+	withHeadroom := length + (length*imageSizeHeadroom+99)/100
+	units := (withHeadroom + imageSizeUnit - 1) / imageSizeUnit
+	if units < 1 {
+		units = 1
+	}
+
+	return fmt.Sprintf("%dGi", units)
+}
+
+// repositoryClient is the part of the kubeberth client used to inspect a
+// repository.
+type repositoryClient interface {
+	GetRepository(ctx context.Context, r *kubeberth.RequestRepository) (*kubeberth.ResponseRepository, error)
+}
+
+// imageLength returns the length of the image in sourceFile, or of the one
+// at repository as reported by kubeberth. If kubeberth cannot tell, http and
+// https repositories are asked with a HEAD request.
+func imageLength(ctx context.Context, client repositoryClient, repository string, credentials *kubeberth.RepositoryCredentials, sourceFile string) (int64, error) {
+	if sourceFile != "" {
+		info, err := os.Stat(sourceFile)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+
+	response, err := client.GetRepository(ctx, &kubeberth.RequestRepository{
+		URL:                   repository,
+		RepositoryCredentials: credentials,
+	})
+	tflog.Trace(ctx, fmt.Sprintf("repository: %+v\n", response))
+	if err == nil && response.Size > 0 {
+		return response.Size, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("kubeberth did not report the size of %s", repository)
+	}
+
+	u, parseErr := url.Parse(repository)
+	if parseErr != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return 0, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("unable to get the length of %s from kubeberth, sending a HEAD request: %v", repository, err))
+
+	// Credentials are never sent in the clear.
+	if u.Scheme != "https" {
+		credentials = nil
+	}
+
+	length, headErr := headContentLength(ctx, repository, credentials)
+	if headErr != nil {
+		return 0, headErr
+	}
+	if length <= 0 {
+		return 0, err
+	}
+
+	return length, nil
+}
+
+func headContentLength(ctx context.Context, repository string, credentials *kubeberth.RepositoryCredentials) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, headRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, repository, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Credentials stored in a kubeberth secret are only available to
+	// kubeberth itself.
+	if credentials != nil {
+		switch {
+		case credentials.Username != "":
+			req.SetBasicAuth(credentials.Username, credentials.Password)
+		case credentials.Token != "":
+			req.Header.Set("Authorization", "Bearer "+credentials.Token)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HEAD %s: %s", repository, resp.Status)
+	}
+
+	return resp.ContentLength, nil
+}
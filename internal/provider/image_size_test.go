@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+)
+
+func TestImageSize(t *testing.T) {
+	tests := map[string]struct {
+		length int64
+		want   string
+	}{
+		"empty":               {length: 0, want: "1Gi"},
+		"small":               {length: 1 << 20, want: "1Gi"},
+		"fits with headroom":  {length: 900 << 20, want: "1Gi"},
+		"headroom overflows":  {length: 1000 << 20, want: "2Gi"},
+		"exactly one Gi":      {length: 1 << 30, want: "2Gi"},
+		"large":               {length: 4 << 30, want: "5Gi"},
+		"headroom rounded up": {length: 10 << 30, want: "11Gi"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := imageSize(test.length); got != test.want {
+				t.Errorf("imageSize(%d) = %s, want %s", test.length, got, test.want)
+			}
+		})
+	}
+}
+
+// fakeRepositoryClient reports size for any repository, or fails with err.
+type fakeRepositoryClient struct {
+	size     int64
+	err      error
+	requests []*kubeberth.RequestRepository
+}
+
+func (c *fakeRepositoryClient) GetRepository(ctx context.Context, r *kubeberth.RequestRepository) (*kubeberth.ResponseRepository, error) {
+	c.requests = append(c.requests, r)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &kubeberth.ResponseRepository{URL: r.URL, Size: c.size}, nil
+}
+
+// testHeadServer serves HEAD requests with a Content-Length of length and
+// records the Authorization header of each.
+type testHeadServer struct {
+	length         string
+	authorizations []string
+}
+
+func (s *testHeadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.authorizations = append(s.authorizations, r.Header.Get("Authorization"))
+	w.Header().Set("Content-Length", s.length)
+}
+
+func TestImageLength(t *testing.T) {
+	credentials := &kubeberth.RepositoryCredentials{Token: "secret"}
+
+	tests := map[string]struct {
+		tls        bool
+		repository string
+		client     *fakeRepositoryClient
+		want       int64
+		wantError  bool
+		// wantAuthorizations are the Authorization headers the HEAD
+		// requests are expected to carry.
+		wantAuthorizations []string
+	}{
+		"reported by kubeberth": {
+			client: &fakeRepositoryClient{size: 1234},
+			want:   1234,
+		},
+		"http fallback without credentials": {
+			client:             &fakeRepositoryClient{err: errors.New("unreachable")},
+			want:               5678,
+			wantAuthorizations: []string{""},
+		},
+		"https fallback with credentials": {
+			tls:                true,
+			client:             &fakeRepositoryClient{err: errors.New("unreachable")},
+			want:               5678,
+			wantAuthorizations: []string{"Bearer secret"},
+		},
+		"https fallback when kubeberth reports no size": {
+			tls:                true,
+			client:             &fakeRepositoryClient{},
+			want:               5678,
+			wantAuthorizations: []string{"Bearer secret"},
+		},
+		"no fallback for s3": {
+			repository: "s3://images/ubuntu.iso",
+			client:     &fakeRepositoryClient{err: errors.New("unreachable")},
+			wantError:  true,
+		},
+		"no fallback for oci": {
+			repository: "oci://registry.example.com/images/ubuntu:22.04",
+			client:     &fakeRepositoryClient{},
+			wantError:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := &testHeadServer{length: "5678"}
+			server := httptest.NewServer(handler)
+			if test.tls {
+				server.Close()
+				server = httptest.NewTLSServer(handler)
+			}
+			defer server.Close()
+
+			defaultClient := http.DefaultClient
+			http.DefaultClient = server.Client()
+			defer func() { http.DefaultClient = defaultClient }()
+
+			repository := test.repository
+			if repository == "" {
+				repository = server.URL + "/ubuntu.iso"
+			}
+
+			got, err := imageLength(context.Background(), test.client, repository, credentials, "")
+			if test.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got length %d", got)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.want {
+					t.Errorf("got length %d, want %d", got, test.want)
+				}
+			}
+
+			if len(test.client.requests) != 1 || test.client.requests[0].URL != repository {
+				t.Errorf("expected kubeberth to be asked about %s first, got %+v", repository, test.client.requests)
+			}
+			if len(handler.authorizations) != len(test.wantAuthorizations) {
+				t.Fatalf("got %d HEAD requests, want %d", len(handler.authorizations), len(test.wantAuthorizations))
+			}
+			for i, want := range test.wantAuthorizations {
+				if handler.authorizations[i] != want {
+					t.Errorf("HEAD request %d: got Authorization %q, want %q", i, handler.authorizations[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestImageLengthSourceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(path, make([]byte, 4096), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &fakeRepositoryClient{}
+	got, err := imageLength(context.Background(), client, "", nil, path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != 4096 {
+		t.Errorf("got length %d, want 4096", got)
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("expected kubeberth not to be asked, got %+v", client.requests)
+	}
+
+	if _, err := imageLength(context.Background(), client, "", nil, filepath.Join(t.TempDir(), "missing.iso")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	uploadRetries     = 3
+	uploadLogInterval = 10 // percent
+)
+
+var (
+	uploadChunkSize  = 8 << 20
+	uploadRetryDelay = 2 * time.Second
+)
+
+// addSourceFileAttributes adds the attributes of the resources that can
+// upload their image from a local file instead of importing it from a
+// repository.
+func addSourceFileAttributes(attributes map[string]tfsdk.Attribute) map[string]tfsdk.Attribute {
+	attributes["source_file"] = tfsdk.Attribute{
+		MarkdownDescription: "Path of a local image to upload to kubeberth. Conflicts with `repository`. Plans read the whole file to hash it whenever its size or modification time changes.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["source_file_hash"] = tfsdk.Attribute{
+		MarkdownDescription: "Checksum of `source_file`, `sha256:` followed by the hex digest. Changing the file forces a new resource.",
+		Type:                types.StringType,
+		Computed:            true,
+	}
+	attributes["source_file_stat"] = tfsdk.Attribute{
+		MarkdownDescription: "Size and modification time of `source_file` when `source_file_hash` was computed. While they match, plans reuse `source_file_hash` instead of reading the file again.",
+		Type:                types.StringType,
+		Computed:            true,
+	}
+
+	return attributes
+}
+
+// fileChecksum returns the sha256 checksum of the file at path in the form
+// used by the checksum attributes.
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sourceFileHash returns the checksum of sourceFile and the stat it was
+// computed at. Hashing a large image reads all of it, so the prior checksum
+// is reused while the path, size and modification time of the file match
+// the prior ones. A file touched but not changed keeps its prior stat, so
+// that it does not show as a change.
+func sourceFileHash(sourceFile, priorSourceFile, priorHash, priorStat types.String) (types.String, types.String, error) {
+	info, err := os.Stat(sourceFile.Value)
+	if err != nil {
+		return types.String{}, types.String{}, err
+	}
+
+	stat := fmt.Sprintf("%d bytes, modified %s", info.Size(), info.ModTime().UTC().Format(time.RFC3339Nano))
+	if sourceFile.Equal(priorSourceFile) && !priorHash.Null && !priorHash.Unknown && priorStat.Value == stat {
+		return priorHash, priorStat, nil
+	}
+
+	checksum, err := fileChecksum(sourceFile.Value)
+	if err != nil {
+		return types.String{}, types.String{}, err
+	}
+
+	hash := types.String{Value: checksum}
+	if hash.Equal(priorHash) {
+		return hash, priorStat, nil
+	}
+
+	return hash, types.String{Value: stat}, nil
+}
+
+// validateImageSource checks that exactly one of repository and source_file
+// is set.
+func validateImageSource(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var repository, sourceFile types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("repository"), &repository)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("source_file"), &sourceFile)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if !repository.Null && !sourceFile.Null {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("source_file"), "Conflicting Attributes",
+			"Only one of repository and source_file can be set.")
+	}
+	if repository.Null && sourceFile.Null {
+		diagnostics.AddError("Missing Attribute", "One of repository or source_file must be set.")
+	}
+}
+
+// modifyPlanSourceFile plans the checksum of source_file, replacing the
+// resource when the file, or the choice between repository and source_file,
+// changes. The computed results of the import are then unknown unless
+// configured.
+func modifyPlanSourceFile(ctx context.Context, computed []string, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	sourceFilePath := tftypes.NewAttributePath().WithAttributeName("source_file")
+	hashPath := tftypes.NewAttributePath().WithAttributeName("source_file_hash")
+	statPath := tftypes.NewAttributePath().WithAttributeName("source_file_stat")
+
+	var sourceFile types.String
+	diags := req.Plan.GetAttribute(ctx, sourceFilePath, &sourceFile)
+	resp.Diagnostics.Append(diags...)
+
+	// There is no prior state when the resource is being created.
+	priorSourceFile, priorHash, priorStat := types.String{Null: true}, types.String{Null: true}, types.String{Null: true}
+	if !req.State.Raw.IsNull() {
+		diags = req.State.GetAttribute(ctx, sourceFilePath, &priorSourceFile)
+		diags.Append(req.State.GetAttribute(ctx, hashPath, &priorHash)...)
+		diags.Append(req.State.GetAttribute(ctx, statPath, &priorStat)...)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, stat := types.String{Null: true}, types.String{Null: true}
+	switch {
+	case sourceFile.Unknown:
+		hash, stat = types.String{Unknown: true}, types.String{Unknown: true}
+	case !sourceFile.Null:
+		var err error
+		hash, stat, err = sourceFileHash(sourceFile, priorSourceFile, priorHash, priorStat)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(sourceFilePath, "Unable to Read Source File", err.Error())
+			return
+		}
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, hashPath, hash)
+	diags.Append(resp.Plan.SetAttribute(ctx, statPath, stat)...)
+	resp.Diagnostics.Append(diags...)
+
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() || hash.Equal(priorHash) {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, hashPath)
+	planUnknownUnlessConfigured(ctx, computed, req, resp)
+}
+
+// uploadClient is the part of the kubeberth client used to upload images.
+type uploadClient interface {
+	CreateUpload(ctx context.Context, r *kubeberth.RequestUpload) (*kubeberth.ResponseUpload, error)
+	GetUpload(ctx context.Context, id string) (*kubeberth.ResponseUpload, error)
+	UploadChunk(ctx context.Context, id string, offset int64, chunk []byte) (*kubeberth.ResponseUpload, error)
+}
+
+// uploadImage uploads the file at path to kubeberth in chunks and returns the
+// ID of the upload. An interrupted upload of the same file, identified by
+// kind, name and checksum, resumes where kubeberth left off. kubeberth
+// rejects the upload if the content does not match checksum, e.g. because
+// the file changed since the plan.
+func uploadImage(ctx context.Context, client uploadClient, kind string, name string, path string, checksum string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+
+	upload, err := client.CreateUpload(ctx, &kubeberth.RequestUpload{
+		Kind:     kind,
+		Name:     name,
+		Size:     size,
+		Checksum: checksum,
+	})
+	tflog.Trace(ctx, fmt.Sprintf("upload: %+v\n", upload))
+	if err != nil {
+		return "", err
+	}
+
+	if upload.Offset > 0 {
+		tflog.Info(ctx, fmt.Sprintf("resuming upload of %s %q at %d of %d bytes", kind, name, upload.Offset, size))
+	}
+
+	chunk := make([]byte, uploadChunkSize)
+	logged := uploadProgress(upload.Offset, size) / uploadLogInterval
+	attempts := 0
+	for upload.Offset < size {
+		n, err := file.ReadAt(chunk, upload.Offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+
+		uploaded, err := client.UploadChunk(ctx, upload.ID, upload.Offset, chunk[:n])
+		if err != nil {
+			attempts++
+			if attempts > uploadRetries {
+				return "", fmt.Errorf("upload of %s %q failed at %d of %d bytes: %w", kind, name, upload.Offset, size, err)
+			}
+			tflog.Warn(ctx, fmt.Sprintf("retrying upload of %s %q at %d of %d bytes: %s", kind, name, upload.Offset, size, err))
+
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(uploadRetryDelay):
+			}
+
+			// Continue from whatever kubeberth has received.
+			if current, err := client.GetUpload(ctx, upload.ID); err == nil {
+				upload = current
+			}
+			continue
+		}
+		attempts = 0
+		upload = uploaded
+
+		if progress := uploadProgress(upload.Offset, size); progress/uploadLogInterval > logged {
+			logged = progress / uploadLogInterval
+			tflog.Info(ctx, fmt.Sprintf("uploading %s %q: %d%%", kind, name, progress))
+		}
+	}
+
+	if upload.Checksum != "" && upload.Checksum != checksum {
+		return "", fmt.Errorf("checksum mismatch for the upload of %s %q: expected %s, got %s", kind, name, checksum, upload.Checksum)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("uploaded %s %q, %d bytes", kind, name, size))
+
+	return upload.ID, nil
+}
+
+func uploadProgress(offset int64, size int64) int64 {
+	if size == 0 {
+		return 100
+	}
+
+	return offset * 100 / size
+}
@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeUploadClient keeps the bytes of a single upload in memory.
+type fakeUploadClient struct {
+	size     int64
+	received []byte
+	checksum string
+	// failures is the number of chunks to fail, after keeping partial bytes
+	// of each.
+	failures int
+	partial  int
+	offsets  []int64
+}
+
+func (c *fakeUploadClient) status() *kubeberth.ResponseUpload {
+	upload := &kubeberth.ResponseUpload{ID: "upload-1", Size: c.size, Offset: int64(len(c.received))}
+	if upload.Offset == c.size {
+		upload.Checksum = c.checksum
+	}
+
+	return upload
+}
+
+func (c *fakeUploadClient) CreateUpload(ctx context.Context, r *kubeberth.RequestUpload) (*kubeberth.ResponseUpload, error) {
+	c.size = r.Size
+	if c.checksum == "" {
+		c.checksum = r.Checksum
+	}
+
+	return c.status(), nil
+}
+
+func (c *fakeUploadClient) GetUpload(ctx context.Context, id string) (*kubeberth.ResponseUpload, error) {
+	return c.status(), nil
+}
+
+func (c *fakeUploadClient) UploadChunk(ctx context.Context, id string, offset int64, chunk []byte) (*kubeberth.ResponseUpload, error) {
+	c.offsets = append(c.offsets, offset)
+	if offset != int64(len(c.received)) {
+		return nil, errors.New("unexpected offset")
+	}
+
+	if c.failures > 0 {
+		c.failures--
+		n := c.partial
+		if n > len(chunk) {
+			n = len(chunk)
+		}
+		c.received = append(c.received, chunk[:n]...)
+		return nil, errors.New("connection reset")
+	}
+
+	c.received = append(c.received, chunk...)
+	return c.status(), nil
+}
+
+func TestUploadImage(t *testing.T) {
+	defer func(chunkSize int, delay time.Duration) {
+		uploadChunkSize, uploadRetryDelay = chunkSize, delay
+	}(uploadChunkSize, uploadRetryDelay)
+	uploadChunkSize, uploadRetryDelay = 4, time.Millisecond
+
+	content := []byte("0123456789")
+	path := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		client      *fakeUploadClient
+		wantOffsets []int64
+		wantError   bool
+	}{
+		"fresh": {
+			client:      &fakeUploadClient{},
+			wantOffsets: []int64{0, 4, 8},
+		},
+		"resumed": {
+			client:      &fakeUploadClient{received: []byte("012345")},
+			wantOffsets: []int64{6},
+		},
+		"retried from the received offset": {
+			client:      &fakeUploadClient{failures: 2, partial: 1},
+			wantOffsets: []int64{0, 1, 2, 6},
+		},
+		"too many failures": {
+			client:    &fakeUploadClient{failures: uploadRetries + 1},
+			wantError: true,
+		},
+		"checksum mismatch": {
+			client:    &fakeUploadClient{checksum: "sha256:0000"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := uploadImage(context.Background(), test.client, "isoimage", "ubuntu", path, checksum)
+			if test.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got upload %q", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if id != "upload-1" {
+				t.Errorf("expected upload-1, got %q", id)
+			}
+			if !bytes.Equal(test.client.received, content) {
+				t.Errorf("expected %q to be uploaded, got %q", content, test.client.received)
+			}
+			if len(test.client.offsets) != len(test.wantOffsets) {
+				t.Fatalf("expected chunks at %v, got %v", test.wantOffsets, test.client.offsets)
+			}
+			for i := range test.wantOffsets {
+				if test.client.offsets[i] != test.wantOffsets[i] {
+					t.Fatalf("expected chunks at %v, got %v", test.wantOffsets, test.client.offsets)
+				}
+			}
+		})
+	}
+}
+
+func TestUploadImageMissingFile(t *testing.T) {
+	_, err := uploadImage(context.Background(), &fakeUploadClient{}, "isoimage", "ubuntu", filepath.Join(t.TempDir(), "missing.iso"), "")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestFileChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(path, []byte("test"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := fileChecksum(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != testChecksum {
+		t.Errorf("expected %s, got %s", testChecksum, got)
+	}
+}
+
+func TestValidateImageSource(t *testing.T) {
+	tests := map[string]struct {
+		attributes map[string]interface{}
+		wantErrors int
+	}{
+		"repository": {
+			attributes: map[string]interface{}{"repository": "https://example.com/ubuntu.iso"},
+		},
+		"source_file": {
+			attributes: map[string]interface{}{"source_file": "ubuntu.iso"},
+		},
+		"both": {
+			attributes: map[string]interface{}{
+				"repository":  "https://example.com/ubuntu.iso",
+				"source_file": "ubuntu.iso",
+			},
+			wantErrors: 1,
+		},
+		"neither": {
+			attributes: map[string]interface{}{},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, isoimageResourceType{}, test.attributes)
+
+			var diags diag.Diagnostics
+			validateImageSource(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestModifyPlanSourceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(path, []byte("terraform-acc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := fileChecksum(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := map[string]interface{}{
+		"name":             "test",
+		"source_file":      path,
+		"source_file_hash": "sha256:" + strings.Repeat("0", 64),
+		"size":             "1Gi",
+		"checksum":         "sha256:" + strings.Repeat("0", 64),
+	}
+
+	tests := map[string]struct {
+		config       map[string]interface{}
+		priorHash    string
+		wantReplace  bool
+		wantSize     types.String
+		wantChecksum types.String
+	}{
+		"unchanged": {
+			config:       map[string]interface{}{"name": "test", "source_file": path},
+			priorHash:    hash,
+			wantSize:     types.String{Value: "1Gi"},
+			wantChecksum: types.String{Value: "sha256:" + strings.Repeat("0", 64)},
+		},
+		"changed": {
+			config:       map[string]interface{}{"name": "test", "source_file": path},
+			wantReplace:  true,
+			wantSize:     types.String{Unknown: true},
+			wantChecksum: types.String{Unknown: true},
+		},
+		"changed with configured size": {
+			config:       map[string]interface{}{"name": "test", "source_file": path, "size": "1Gi"},
+			wantReplace:  true,
+			wantSize:     types.String{Value: "1Gi"},
+			wantChecksum: types.String{Unknown: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			priorState := map[string]interface{}{}
+			for k, v := range state {
+				priorState[k] = v
+			}
+			if test.priorHash != "" {
+				priorState["source_file_hash"] = test.priorHash
+			}
+
+			config := testConfig(t, isoimageResourceType{}, test.config)
+			// The plan starts as the prior state, as for an unconfigured
+			// attribute planned with UseStateForUnknown.
+			prior := testConfig(t, isoimageResourceType{}, priorState)
+
+			req := tfsdk.ModifyResourcePlanRequest{
+				Config: config,
+				State:  tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+				Plan:   tfsdk.Plan{Schema: prior.Schema, Raw: prior.Raw},
+			}
+			resp := &tfsdk.ModifyResourcePlanResponse{Plan: req.Plan}
+
+			modifyPlanSourceFile(ctx, []string{"size", "checksum"}, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if got := len(resp.RequiresReplace) > 0; got != test.wantReplace {
+				t.Errorf("got RequiresReplace %v, want replace %v", resp.RequiresReplace, test.wantReplace)
+			}
+
+			var data isoimageResourceData
+			if diags := resp.Plan.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if data.SourceFileHash.Value != hash {
+				t.Errorf("got source_file_hash %s, want %s", data.SourceFileHash.Value, hash)
+			}
+			if !data.Size.Equal(test.wantSize) {
+				t.Errorf("got size %v, want %v", data.Size, test.wantSize)
+			}
+			if !data.Checksum.Equal(test.wantChecksum) {
+				t.Errorf("got checksum %v, want %v", data.Checksum, test.wantChecksum)
+			}
+		})
+	}
+}
+
+func TestSourceFileHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(path, []byte("terraform-acc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sourceFile := types.String{Value: path}
+	null := types.String{Null: true}
+	stale := types.String{Value: "sha256:" + strings.Repeat("0", 64)}
+
+	hash, stat, err := sourceFileHash(sourceFile, null, null, null)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hash.Value != "sha256:46a4eeb273965740de216e40cd017f503d6b16d55f0748964b1684a229a4e3ad" {
+		t.Errorf("got hash %s", hash.Value)
+	}
+	if stat.Null || stat.Value == "" {
+		t.Fatal("expected the stat of the file")
+	}
+
+	otherStat := types.String{Value: "13 bytes, modified 2022-01-01T00:00:00Z"}
+
+	tests := map[string]struct {
+		priorSourceFile types.String
+		priorHash       types.String
+		priorStat       types.String
+		wantHash        types.String
+		wantStat        types.String
+	}{
+		"unchanged file is not read": {
+			priorSourceFile: sourceFile,
+			priorHash:       stale,
+			priorStat:       stat,
+			wantHash:        stale,
+			wantStat:        stat,
+		},
+		"touched file keeps its stat": {
+			priorSourceFile: sourceFile,
+			priorHash:       hash,
+			priorStat:       otherStat,
+			wantHash:        hash,
+			wantStat:        otherStat,
+		},
+		"changed file": {
+			priorSourceFile: sourceFile,
+			priorHash:       stale,
+			priorStat:       otherStat,
+			wantHash:        hash,
+			wantStat:        stat,
+		},
+		"moved file": {
+			priorSourceFile: types.String{Value: filepath.Join(filepath.Dir(path), "old.iso")},
+			priorHash:       stale,
+			priorStat:       stat,
+			wantHash:        hash,
+			wantStat:        stat,
+		},
+		"no prior stat": {
+			priorSourceFile: sourceFile,
+			priorHash:       hash,
+			priorStat:       null,
+			wantHash:        hash,
+			wantStat:        null,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotHash, gotStat, err := sourceFileHash(sourceFile, test.priorSourceFile, test.priorHash, test.priorStat)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !gotHash.Equal(test.wantHash) {
+				t.Errorf("got hash %v, want %v", gotHash, test.wantHash)
+			}
+			if !gotStat.Equal(test.wantStat) {
+				t.Errorf("got stat %v, want %v", gotStat, test.wantStat)
+			}
+		})
+	}
+
+	if _, _, err := sourceFileHash(types.String{Value: filepath.Join(t.TempDir(), "missing.iso")}, null, null, null); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
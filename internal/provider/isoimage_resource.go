@@ -25,23 +25,33 @@ func (t isoimageResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(addImportAttributes(addSourceFileAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
 				Required:            true,
 			},
 			"size": {
-				MarkdownDescription: "size",
+				MarkdownDescription: "Size of the volume holding the image, e.g. `5Gi`. If not set, the size of the image plus 10% rounded up to whole Gi, as reported by kubeberth, found with a HEAD request to an `http(s)://` `repository`, or from `source_file`.",
 				Type:                types.StringType,
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
+				Validators: []tfsdk.AttributeValidator{
+					quantityString(),
+				},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
 			},
 			"repository": {
-				MarkdownDescription: "repository",
+				MarkdownDescription: "URL to import the image from: `http://`, `https://`, `s3://bucket/key` or `oci://registry/repository:tag`. Conflicts with `source_file`.",
 				Type:                types.StringType,
-				Required:            true,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					repositoryURL(),
+				},
 			},
-		},
+		})))),
 	}, nil
 }
 
@@ -57,6 +67,21 @@ type isoimageResourceData struct {
 	Name       types.String `tfsdk:"name"`
 	Size       types.String `tfsdk:"size"`
 	Repository types.String `tfsdk:"repository"`
+
+	Checksum      types.String `tfsdk:"checksum"`
+	State         types.String `tfsdk:"state"`
+	ImportTimeout types.String `tfsdk:"import_timeout"`
+
+	RepositoryCredentials *repositoryCredentialsData `tfsdk:"repository_credentials"`
+
+	SourceFile     types.String `tfsdk:"source_file"`
+	SourceFileHash types.String `tfsdk:"source_file_hash"`
+	SourceFileStat types.String `tfsdk:"source_file_stat"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
 }
 
 type isoimageResource struct {
@@ -68,15 +93,82 @@ func createNewISOImage(data *isoimageResourceData) *kubeberth.RequestISOImage {
 		Name:       data.Name.Value,
 		Size:       data.Size.Value,
 		Repository: data.Repository.Value,
+
+		RepositoryCredentials: newRepositoryCredentials(data.RepositoryCredentials),
 	}
 
 	return isoimage
 }
 
+// awaitImport waits for kubeberth to import the isoimage, records the result
+// in data and verifies the configured checksum.
+func (r isoimageResource) awaitImport(ctx context.Context, data *isoimageResourceData) error {
+	client := r.provider.namespacedClient(data.Namespace)
+
+	status, err := waitForImport(ctx, "isoimage", data.Name.Value, importTimeout(data.ImportTimeout), func(ctx context.Context) (*importStatus, error) {
+		isoimage, err := client.GetISOImage(ctx, data.Name.Value)
+		tflog.Trace(ctx, fmt.Sprintf("isoimage: %+v\n", isoimage))
+		if err != nil {
+			return nil, err
+		}
+
+		return &importStatus{
+			State:    isoimage.State,
+			Progress: isoimage.Progress,
+			Message:  isoimage.Message,
+			Checksum: isoimage.Checksum,
+		}, nil
+	})
+	if status == nil {
+		status = &importStatus{}
+	}
+
+	data.State = types.String{Null: status.State == "", Value: status.State}
+	if data.Checksum.Unknown {
+		data.Checksum = refreshChecksum(data.Checksum, status.Checksum)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return verifyChecksum("isoimage", data.Name.Value, data.Checksum, status.Checksum)
+}
+
+// deriveSize sets the size of the isoimage from the length of its image if
+// it is not configured.
+func (r isoimageResource) deriveSize(ctx context.Context, data *isoimageResourceData) error {
+	if !data.Size.Unknown {
+		return nil
+	}
+
+	length, err := imageLength(ctx, r.provider.namespacedClient(data.Namespace), data.Repository.Value, newRepositoryCredentials(data.RepositoryCredentials), data.SourceFile.Value)
+	if err != nil {
+		return err
+	}
+
+	data.Size = types.String{Value: imageSize(length)}
+	tflog.Info(ctx, fmt.Sprintf("sizing isoimage %q at %s for an image of %d bytes", data.Name.Value, data.Size.Value, length))
+
+	return nil
+}
+
+func (r isoimageResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	validateImageSource(ctx, req.Config, &resp.Diagnostics)
+	validateRepositoryCredentials(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r isoimageResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+	modifyPlanSourceFile(ctx, []string{"size", "checksum"}, req, resp)
+	modifyPlanImport(ctx, []string{"size", "checksum"}, req, resp)
+}
+
 func (r isoimageResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data isoimageResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -91,14 +183,41 @@ func (r isoimageResource) Create(ctx context.Context, req tfsdk.CreateResourceRe
 	//     return
 	// }
 
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+
+	if err := r.deriveSize(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to determine the size of isoimage, got error: %s", err))
+		return
+	}
+
 	newISOImage := createNewISOImage(&data)
-	createdISOImage, err := r.provider.client.CreateISOImage(ctx, newISOImage)
+	newISOImage.Labels, newISOImage.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	newISOImage.Namespace = data.Namespace.Value
+
+	if !data.SourceFile.Null {
+		upload, err := uploadImage(ctx, r.provider.namespacedClient(data.Namespace), "isoimage", data.Name.Value, data.SourceFile.Value, data.SourceFileHash.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Upload Error", fmt.Sprintf("Unable to upload %s, got error: %s", data.SourceFile.Value, err))
+			return
+		}
+		newISOImage.Upload = upload
+	}
+
+	createdISOImage, err := r.provider.namespacedClient(data.Namespace).CreateISOImage(ctx, newISOImage)
 	tflog.Trace(ctx, fmt.Sprintf("isoimage: %+v\n", createdISOImage))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create isoimage, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, createdISOImage.Namespace)
+
+	// Save the isoimage even if the import fails, so that it is replaced on the
+	// next apply rather than leaked.
+	if err := r.awaitImport(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Import Error", err.Error())
+	}
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -131,13 +250,23 @@ func (r isoimageResource) Read(ctx context.Context, req tfsdk.ReadResourceReques
 	//     return
 	// }
 
-	isoimage, err := r.provider.client.GetISOImage(ctx, data.Name.Value)
+	isoimage, err := r.provider.namespacedClient(data.Namespace).GetISOImage(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("isoimage: %+v\n", isoimage))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read isoimage, got error: %s", err))
 		return
 	}
 
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, isoimage.Labels, isoimage.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, isoimage.Namespace)
+
+	data.Repository = types.String{Null: isoimage.Repository == "", Value: isoimage.Repository}
+	if !quantitiesEqual(data.Size.Value, isoimage.Size) {
+		data.Size = types.String{Null: isoimage.Size == "", Value: isoimage.Size}
+	}
+	data.State = types.String{Null: isoimage.State == "", Value: isoimage.State}
+	data.Checksum = refreshChecksum(data.Checksum, isoimage.Checksum)
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -145,11 +274,14 @@ func (r isoimageResource) Read(ctx context.Context, req tfsdk.ReadResourceReques
 }
 
 func (r isoimageResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data isoimageResourceData
+	var data, state isoimageResourceData
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -162,14 +294,38 @@ func (r isoimageResource) Update(ctx context.Context, req tfsdk.UpdateResourceRe
 	//     return
 	// }
 
+	// An unconfigured size is only unknown when modifyPlanImport or
+	// modifyPlanSourceFile found the image changed, so kubeberth imports it
+	// again and it is sized afresh.
+	if err := r.deriveSize(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to determine the size of isoimage, got error: %s", err))
+		return
+	}
+
 	newISOImage := createNewISOImage(&data)
-	updatedISOImage, err := r.provider.client.UpdateISOImage(ctx, data.Name.Value, newISOImage)
+	newISOImage.Labels, newISOImage.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	newISOImage.Namespace = data.Namespace.Value
+
+	updatedISOImage, err := r.provider.namespacedClient(data.Namespace).UpdateISOImage(ctx, data.Name.Value, newISOImage)
 	tflog.Trace(ctx, fmt.Sprintf("isoimage: %+v\n", updatedISOImage))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update isoimage, got error: %s", err))
 		return
 	}
 
+	// Changing only labels or annotations leaves the imported image, or a
+	// failed import, alone.
+	if importPending(state.State, data.Repository, state.Repository, data.SourceFileHash, state.SourceFileHash) {
+		if err := r.awaitImport(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Import Error", err.Error())
+		}
+	} else {
+		data.State = state.State
+		if data.Checksum.Unknown {
+			data.Checksum = state.Checksum
+		}
+	}
+
 	tflog.Trace(ctx, "updated a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -194,7 +350,7 @@ func (r isoimageResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteISOImage(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteISOImage(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("isoimage: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete isoimage, got error: %s", err))
@@ -203,5 +359,5 @@ func (r isoimageResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 }
 
 func (r isoimageResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccISOImageResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccISOImageResourceConfig("http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04.4-live-server-arm64.iso"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "name", "terraform-acc-isoimage"),
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "repository", "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04.4-live-server-arm64.iso"),
+					resource.TestMatchResourceAttr("kubeberth_isoimage.test", "size", regexp.MustCompile(`^[1-9][0-9]*Gi$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "kubeberth_isoimage.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_isoimage.test", "name"),
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccISOImageResourceConfig("http://minio.home.arpa:9000/kubevirt/images/ubuntu-22.04-live-server-arm64.iso"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "repository", "http://minio.home.arpa:9000/kubevirt/images/ubuntu-22.04-live-server-arm64.iso"),
+					resource.TestMatchResourceAttr("kubeberth_isoimage.test", "size", regexp.MustCompile(`^[1-9][0-9]*Gi$`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccISOImageResourceConfig(repository string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_isoimage" "test" {
+  name       = "terraform-acc-isoimage"
+  repository = %[1]q
+}
+`, repository)
+}
+
+func TestAccISOImageResourceSourceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terraform-acc.iso")
+	if err := os.WriteFile(path, []byte("terraform-acc"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccISOImageResourceSourceFileConfig(path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "source_file", path),
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "source_file_hash", "sha256:46a4eeb273965740de216e40cd017f503d6b16d55f0748964b1684a229a4e3ad"),
+					resource.TestCheckResourceAttrSet("kubeberth_isoimage.test", "source_file_stat"),
+					resource.TestCheckResourceAttr("kubeberth_isoimage.test", "state", "Created"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "kubeberth_isoimage.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportStateIdFunc("kubeberth_isoimage.test", "name"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source_file", "source_file_hash", "source_file_stat"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccISOImageResourceSourceFileConfig(path string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_isoimage" "test" {
+  name        = "terraform-acc-isoimage-upload"
+  size        = "1Gi"
+  source_file = %[1]q
+}
+`, path)
+}
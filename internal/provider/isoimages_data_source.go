@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = isoimagesDataSourceType{}
+var _ tfsdk.DataSource = isoimagesDataSource{}
+
+type isoimagesDataSourceType struct{}
+
+func (t isoimagesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["state"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return isoimages in this state.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["isoimages"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching isoimages, sorted by name.",
+		Computed:            true,
+		Attributes:          tfsdk.ListNestedAttributes(isoimageAttributes(), tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth isoimages.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+// isoimageAttributes returns the computed attributes describing an isoimage.
+func isoimageAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name": {
+			MarkdownDescription: "name",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"repository": {
+			MarkdownDescription: "repository",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"size": {
+			MarkdownDescription: "size",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"state": {
+			MarkdownDescription: "state",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"checksum": {
+			MarkdownDescription: "checksum",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (t isoimagesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return isoimagesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type isoimageDataSourceData struct {
+	Name       types.String `tfsdk:"name"`
+	Repository types.String `tfsdk:"repository"`
+	Size       types.String `tfsdk:"size"`
+	State      types.String `tfsdk:"state"`
+	Checksum   types.String `tfsdk:"checksum"`
+}
+
+func newISOImageDataSourceData(isoimage *kubeberth.ResponseISOImage) *isoimageDataSourceData {
+	return &isoimageDataSourceData{
+		Name:       types.String{Value: isoimage.Name},
+		Repository: types.String{Value: isoimage.Repository},
+		Size:       types.String{Value: isoimage.Size},
+		State:      types.String{Value: isoimage.State},
+		Checksum:   types.String{Value: strings.ToLower(isoimage.Checksum)},
+	}
+}
+
+type isoimagesDataSourceData struct {
+	NameRegex types.String             `tfsdk:"name_regex"`
+	Labels    map[string]string        `tfsdk:"labels"`
+	State     types.String             `tfsdk:"state"`
+	ISOImages []isoimageDataSourceData `tfsdk:"isoimages"`
+}
+
+// match reports whether isoimage passes the filters of the data source.
+func (data *isoimagesDataSourceData) match(filter *listFilter, isoimage *kubeberth.ResponseISOImage) bool {
+	if !filter.match(isoimage.Name, isoimage.Labels) {
+		return false
+	}
+	if !data.State.Null && isoimage.State != data.State.Value {
+		return false
+	}
+
+	return true
+}
+
+type isoimagesDataSource struct {
+	provider provider
+}
+
+func (d isoimagesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data isoimagesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseISOImages, err := d.provider.client.GetAllISOImages(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("isoimages: %+v\n", responseISOImages))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list isoimages, got error: %s", err))
+		return
+	}
+
+	data.ISOImages = []isoimageDataSourceData{}
+	for i := range responseISOImages {
+		isoimage := &responseISOImages[i]
+		if !data.match(filter, isoimage) {
+			continue
+		}
+		data.ISOImages = append(data.ISOImages, *newISOImageDataSourceData(isoimage))
+	}
+
+	sort.Slice(data.ISOImages, func(i, j int) bool {
+		return data.ISOImages[i].Name.Value < data.ISOImages[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccISOImagesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccISOImagesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_isoimages.test", "isoimages.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_isoimages.test", "isoimages.0.name", "kubeberth_isoimage.test", "name"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_isoimages.test", "isoimages.0.repository", "kubeberth_isoimage.test", "repository"),
+				),
+			},
+		},
+	})
+}
+
+const testAccISOImagesDataSourceConfig = `
+resource "kubeberth_isoimage" "test" {
+  name       = "terraform-acc-isoimages"
+  repository = "http://minio.home.arpa:9000/kubevirt/images/ubuntu-20.04.4-live-server-arm64.iso"
+
+  labels = {
+    "terraform-acc" = "isoimages"
+  }
+}
+
+data "kubeberth_isoimages" "test" {
+  labels = {
+    "terraform-acc" = kubeberth_isoimage.test.labels["terraform-acc"]
+  }
+  state = kubeberth_isoimage.test.state
+}
+`
+
+func TestISOImagesDataSourceMatch(t *testing.T) {
+	isoimage := &kubeberth.ResponseISOImage{
+		Name:   "ubuntu",
+		State:  "Created",
+		Labels: map[string]string{"os": "ubuntu"},
+	}
+
+	tests := map[string]struct {
+		labels map[string]string
+		state  types.String
+		want   bool
+	}{
+		"no filters": {
+			state: types.String{Null: true},
+			want:  true,
+		},
+		"state matches": {
+			state: types.String{Value: "Created"},
+			want:  true,
+		},
+		"state differs": {
+			state: types.String{Value: "Importing"},
+			want:  false,
+		},
+		"labels and state match": {
+			labels: map[string]string{"os": "ubuntu"},
+			state:  types.String{Value: "Created"},
+			want:   true,
+		},
+		"labels differ": {
+			labels: map[string]string{"os": "debian"},
+			state:  types.String{Value: "Created"},
+			want:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := isoimagesDataSourceData{Labels: test.labels, State: test.state}
+
+			filter, err := newListFilter(types.String{Null: true}, test.labels)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := data.match(filter, isoimage); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// addLabelAttributes adds the metadata attributes shared by every resource
+// backed by a kubeberth object.
+func addLabelAttributes(attributes map[string]tfsdk.Attribute) map[string]tfsdk.Attribute {
+	attributes["labels"] = tfsdk.Attribute{
+		MarkdownDescription: "Labels to set on the object. Take precedence over the provider `default_labels`.",
+		Type:                types.MapType{ElemType: types.StringType},
+		Optional:            true,
+	}
+	attributes["annotations"] = tfsdk.Attribute{
+		MarkdownDescription: "Annotations to set on the object.",
+		Type:                types.MapType{ElemType: types.StringType},
+		Optional:            true,
+	}
+	attributes["all_labels"] = tfsdk.Attribute{
+		MarkdownDescription: "All labels set on the object: the provider `default_labels` merged with `labels`.",
+		Type:                types.MapType{ElemType: types.StringType},
+		Computed:            true,
+	}
+
+	return attributes
+}
+
+// mergeLabels returns the provider default labels overridden by labels.
+func (p provider) mergeLabels(labels map[string]string) map[string]string {
+	merged := map[string]string{}
+	for key, value := range p.defaultLabels {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// requestLabels returns the labels and annotations to send to kubeberth when
+// creating or updating an object, and sets allLabels to the labels sent. The
+// labels are the planned all_labels, or the merged labels if the plan could
+// not know them yet.
+func (p provider) requestLabels(ctx context.Context, labels map[string]string, annotations map[string]string, allLabels *types.Map) (map[string]string, map[string]string) {
+	merged := map[string]string{}
+	if allLabels.Null || allLabels.Unknown || allLabels.ElementsAs(ctx, &merged, false).HasError() {
+		merged = p.mergeLabels(labels)
+	}
+
+	*allLabels = newLabelsMap(merged)
+
+	return merged, annotations
+}
+
+// modifyPlanLabels plans all_labels so that the merged labels show up in the
+// plan.
+func modifyPlanLabels(ctx context.Context, p provider, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	diags := req.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("labels"), &labels)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allLabels := types.Map{ElemType: types.StringType, Unknown: true}
+	if !labels.Unknown && !hasUnknownElement(labels) {
+		merged := map[string]string{}
+		if !labels.Null {
+			diags = labels.ElementsAs(ctx, &merged, false)
+			resp.Diagnostics.Append(diags...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		allLabels = newLabelsMap(p.mergeLabels(merged))
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("all_labels"), allLabels)
+	resp.Diagnostics.Append(diags...)
+}
+
+func hasUnknownElement(m types.Map) bool {
+	for _, elem := range m.Elems {
+		if elem.(types.String).Unknown {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newLabelsMap(labels map[string]string) types.Map {
+	m := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for key, value := range labels {
+		m.Elems[key] = types.String{Value: value}
+	}
+
+	return m
+}
+
+// refreshLabels returns the current values of the keys in managed, dropping
+// keys that have been removed. Keys added outside of Terraform, e.g. by the
+// operator, are ignored.
+func refreshLabels(managed map[string]string, current map[string]string) map[string]string {
+	if managed == nil {
+		return nil
+	}
+
+	refreshed := map[string]string{}
+	for key := range managed {
+		if value, ok := current[key]; ok {
+			refreshed[key] = value
+		}
+	}
+
+	return refreshed
+}
+
+// refreshMetadata refreshes labels, annotations and all_labels from the labels
+// and annotations of the object. all_labels is only null right after an
+// import, when nothing is known about the configuration: the labels that do
+// not come from the provider default_labels and all annotations are then
+// taken as configured.
+func (p provider) refreshMetadata(ctx context.Context, labels map[string]string, annotations map[string]string, allLabels types.Map, currentLabels map[string]string, currentAnnotations map[string]string) (map[string]string, map[string]string, types.Map) {
+	if !allLabels.Null {
+		return refreshLabels(labels, currentLabels), refreshLabels(annotations, currentAnnotations), refreshAllLabels(ctx, allLabels, currentLabels)
+	}
+
+	labels = map[string]string{}
+	for key, value := range currentLabels {
+		if defaultValue, ok := p.defaultLabels[key]; !ok || defaultValue != value {
+			labels[key] = value
+		}
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+
+	annotations = nil
+	if len(currentAnnotations) > 0 {
+		annotations = currentAnnotations
+	}
+
+	return labels, annotations, newLabelsMap(currentLabels)
+}
+
+// refreshAllLabels is refreshLabels for the computed all_labels.
+func refreshAllLabels(ctx context.Context, allLabels types.Map, current map[string]string) types.Map {
+	if allLabels.Null || allLabels.Unknown {
+		return types.Map{ElemType: types.StringType, Null: true}
+	}
+
+	managed := map[string]string{}
+	if diags := allLabels.ElementsAs(ctx, &managed, false); diags.HasError() {
+		return allLabels
+	}
+
+	return newLabelsMap(refreshLabels(managed, current))
+}
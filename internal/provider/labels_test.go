@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeLabels(t *testing.T) {
+	tests := map[string]struct {
+		defaultLabels map[string]string
+		labels        map[string]string
+		want          map[string]string
+	}{
+		"none": {
+			want: map[string]string{},
+		},
+		"defaults only": {
+			defaultLabels: map[string]string{"team": "infra"},
+			want:          map[string]string{"team": "infra"},
+		},
+		"labels only": {
+			labels: map[string]string{"role": "web"},
+			want:   map[string]string{"role": "web"},
+		},
+		"merged": {
+			defaultLabels: map[string]string{"team": "infra", "env": "prod"},
+			labels:        map[string]string{"role": "web"},
+			want:          map[string]string{"team": "infra", "env": "prod", "role": "web"},
+		},
+		"labels override defaults": {
+			defaultLabels: map[string]string{"team": "infra", "env": "prod"},
+			labels:        map[string]string{"env": "staging"},
+			want:          map[string]string{"team": "infra", "env": "staging"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := provider{defaultLabels: test.defaultLabels}
+
+			got := p.mergeLabels(test.labels)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRequestLabels(t *testing.T) {
+	p := provider{defaultLabels: map[string]string{"team": "infra", "env": "prod"}}
+
+	tests := map[string]struct {
+		labels    map[string]string
+		allLabels types.Map
+		want      map[string]string
+	}{
+		"planned": {
+			labels:    map[string]string{"role": "web"},
+			allLabels: newLabelsMap(map[string]string{"team": "infra", "env": "prod", "role": "web"}),
+			want:      map[string]string{"team": "infra", "env": "prod", "role": "web"},
+		},
+		"unknown": {
+			labels:    map[string]string{"env": "staging"},
+			allLabels: types.Map{ElemType: types.StringType, Unknown: true},
+			want:      map[string]string{"team": "infra", "env": "staging"},
+		},
+		"null": {
+			allLabels: types.Map{ElemType: types.StringType, Null: true},
+			want:      map[string]string{"team": "infra", "env": "prod"},
+		},
+		"unknown element": {
+			labels: map[string]string{"role": "web"},
+			allLabels: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"role": types.String{Unknown: true},
+			}},
+			want: map[string]string{"team": "infra", "env": "prod", "role": "web"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			annotations := map[string]string{"owner": "alice"}
+			allLabels := test.allLabels
+
+			labels, gotAnnotations := p.requestLabels(context.Background(), test.labels, annotations, &allLabels)
+			if !reflect.DeepEqual(labels, test.want) {
+				t.Errorf("expected labels %v, got %v", test.want, labels)
+			}
+			if !reflect.DeepEqual(gotAnnotations, annotations) {
+				t.Errorf("expected annotations %v, got %v", annotations, gotAnnotations)
+			}
+			if want := newLabelsMap(test.want); !allLabels.Equal(want) {
+				t.Errorf("expected all_labels %v, got %v", want, allLabels)
+			}
+		})
+	}
+}
+
+func TestRefreshLabels(t *testing.T) {
+	tests := map[string]struct {
+		managed map[string]string
+		current map[string]string
+		want    map[string]string
+	}{
+		"unmanaged": {
+			current: map[string]string{"role": "web"},
+			want:    nil,
+		},
+		"unchanged": {
+			managed: map[string]string{"role": "web"},
+			current: map[string]string{"role": "web"},
+			want:    map[string]string{"role": "web"},
+		},
+		"changed": {
+			managed: map[string]string{"role": "web"},
+			current: map[string]string{"role": "db"},
+			want:    map[string]string{"role": "db"},
+		},
+		"removed": {
+			managed: map[string]string{"role": "web", "env": "prod"},
+			current: map[string]string{"role": "web"},
+			want:    map[string]string{"role": "web"},
+		},
+		"added outside of terraform": {
+			managed: map[string]string{"role": "web"},
+			current: map[string]string{"role": "web", "kubeberth.io/owner": "operator"},
+			want:    map[string]string{"role": "web"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshLabels(test.managed, test.current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRefreshAllLabels(t *testing.T) {
+	current := map[string]string{"team": "infra", "role": "db", "kubeberth.io/owner": "operator"}
+
+	got := refreshAllLabels(context.Background(), newLabelsMap(map[string]string{"team": "infra", "role": "web", "env": "prod"}), current)
+	if want := newLabelsMap(map[string]string{"team": "infra", "role": "db"}); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got = refreshAllLabels(context.Background(), types.Map{ElemType: types.StringType, Null: true}, current)
+	if !got.Null {
+		t.Errorf("expected null, got %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listFilterAttributes returns the filter attributes shared by every plural
+// data source. Callers add their own type specific filters and the computed
+// result list on top of these.
+func listFilterAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name_regex": {
+			MarkdownDescription: "Only return objects whose name matches this regular expression.",
+			Type:                types.StringType,
+			Optional:            true,
+		},
+		"labels": {
+			MarkdownDescription: "Only return objects carrying all of these labels.",
+			Type:                types.MapType{ElemType: types.StringType},
+			Optional:            true,
+		},
+	}
+}
+
+type listFilter struct {
+	nameRegex *regexp.Regexp
+	labels    map[string]string
+}
+
+func newListFilter(nameRegex types.String, labels map[string]string) (*listFilter, error) {
+	filter := &listFilter{
+		labels: labels,
+	}
+
+	if !nameRegex.Null && nameRegex.Value != "" {
+		re, err := regexp.Compile(nameRegex.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_regex %q: %w", nameRegex.Value, err)
+		}
+		filter.nameRegex = re
+	}
+
+	return filter, nil
+}
+
+func (f *listFilter) match(name string, labels map[string]string) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+
+	for key, value := range f.labels {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
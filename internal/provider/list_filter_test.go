@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewListFilter(t *testing.T) {
+	tests := map[string]struct {
+		nameRegex types.String
+		wantRegex string
+		wantErr   bool
+	}{
+		"null": {
+			nameRegex: types.String{Null: true},
+		},
+		"empty": {
+			nameRegex: types.String{Value: ""},
+		},
+		"valid": {
+			nameRegex: types.String{Value: "^web-"},
+			wantRegex: "^web-",
+		},
+		"invalid": {
+			nameRegex: types.String{Value: "web-("},
+			wantErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter, err := newListFilter(test.nameRegex, nil)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got filter %+v", filter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := ""
+			if filter.nameRegex != nil {
+				got = filter.nameRegex.String()
+			}
+			if got != test.wantRegex {
+				t.Errorf("expected name regex %q, got %q", test.wantRegex, got)
+			}
+		})
+	}
+}
+
+func TestListFilterMatch(t *testing.T) {
+	tests := map[string]struct {
+		nameRegex    string
+		filterLabels map[string]string
+		name         string
+		labels       map[string]string
+		want         bool
+	}{
+		"no filters": {
+			name: "web-1",
+			want: true,
+		},
+		"name matches": {
+			nameRegex: "^web-",
+			name:      "web-1",
+			want:      true,
+		},
+		"name does not match": {
+			nameRegex: "^web-",
+			name:      "db-1",
+			want:      false,
+		},
+		"name matches unanchored": {
+			nameRegex: "web",
+			name:      "prod-web-1",
+			want:      true,
+		},
+		"labels match": {
+			filterLabels: map[string]string{"role": "web"},
+			name:         "web-1",
+			labels:       map[string]string{"role": "web", "env": "prod"},
+			want:         true,
+		},
+		"label value differs": {
+			filterLabels: map[string]string{"role": "web"},
+			name:         "web-1",
+			labels:       map[string]string{"role": "db"},
+			want:         false,
+		},
+		"label missing": {
+			filterLabels: map[string]string{"role": "web", "env": "prod"},
+			name:         "web-1",
+			labels:       map[string]string{"role": "web"},
+			want:         false,
+		},
+		"nil labels": {
+			filterLabels: map[string]string{"role": "web"},
+			name:         "web-1",
+			want:         false,
+		},
+		"empty label value": {
+			filterLabels: map[string]string{"role": ""},
+			name:         "web-1",
+			labels:       map[string]string{"role": ""},
+			want:         true,
+		},
+		"name and labels": {
+			nameRegex:    "^web-",
+			filterLabels: map[string]string{"role": "web"},
+			name:         "db-1",
+			labels:       map[string]string{"role": "web"},
+			want:         false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nameRegex := types.String{Null: true}
+			if test.nameRegex != "" {
+				nameRegex = types.String{Value: test.nameRegex}
+			}
+
+			filter, err := newListFilter(nameRegex, test.filterLabels)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := filter.match(test.name, test.labels); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+// testListFilter returns a filter accepting every object, for the tests of
+// the type specific filters.
+func testListFilter(t *testing.T) *listFilter {
+	filter, err := newListFilter(types.String{Null: true}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return filter
+}
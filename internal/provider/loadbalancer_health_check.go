@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	healthCheckProtocolTCP   = "TCP"
+	healthCheckProtocolHTTP  = "HTTP"
+	healthCheckProtocolHTTPS = "HTTPS"
+)
+
+// healthCheckAttributes returns the attributes of the health_check block.
+// Unset values are left to the kubeberth defaults.
+func healthCheckAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"protocol": {
+			MarkdownDescription: "How to probe a backend: `TCP` connects to the port, `HTTP` and `HTTPS` expect a 2xx or 3xx response for `path`.",
+			Type:                types.StringType,
+			Required:            true,
+			Validators: []tfsdk.AttributeValidator{
+				stringOneOf(healthCheckProtocolTCP, healthCheckProtocolHTTP, healthCheckProtocolHTTPS),
+			},
+		},
+		"port": {
+			MarkdownDescription: "Port to probe on the backend. Defaults to the `target_port` of each port.",
+			Type:                types.Int64Type,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				int64Between(1, 65535),
+			},
+		},
+		"path": {
+			MarkdownDescription: "Path to request for `HTTP` and `HTTPS` health checks. Defaults to `/`.",
+			Type:                types.StringType,
+			Optional:            true,
+		},
+		"interval": {
+			MarkdownDescription: "Time between two probes, rounded up to whole seconds, e.g. `10s`.",
+			Type:                types.StringType,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				durationString(),
+			},
+		},
+		"timeout": {
+			MarkdownDescription: "Time after which a probe fails, rounded up to whole seconds, e.g. `1s`.",
+			Type:                types.StringType,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				durationString(),
+			},
+		},
+		"healthy_threshold": {
+			MarkdownDescription: "Number of consecutive successful probes before a backend receives traffic again.",
+			Type:                types.Int64Type,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				int64Between(1, 10),
+			},
+		},
+		"unhealthy_threshold": {
+			MarkdownDescription: "Number of consecutive failed probes before a backend stops receiving traffic.",
+			Type:                types.Int64Type,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				int64Between(1, 10),
+			},
+		},
+	}
+}
+
+type healthCheckData struct {
+	Protocol           types.String `tfsdk:"protocol"`
+	Port               types.Int64  `tfsdk:"port"`
+	Path               types.String `tfsdk:"path"`
+	Interval           types.String `tfsdk:"interval"`
+	Timeout            types.String `tfsdk:"timeout"`
+	HealthyThreshold   types.Int64  `tfsdk:"healthy_threshold"`
+	UnhealthyThreshold types.Int64  `tfsdk:"unhealthy_threshold"`
+}
+
+func newHealthCheck(data *healthCheckData) *kubeberth.HealthCheck {
+	if data == nil {
+		return nil
+	}
+
+	return &kubeberth.HealthCheck{
+		Protocol:           data.Protocol.Value,
+		Port:               (int32)(data.Port.Value),
+		Path:               data.Path.Value,
+		IntervalSeconds:    durationSeconds(data.Interval),
+		TimeoutSeconds:     durationSeconds(data.Timeout),
+		HealthyThreshold:   (int32)(data.HealthyThreshold.Value),
+		UnhealthyThreshold: (int32)(data.UnhealthyThreshold.Value),
+	}
+}
+
+// durationSeconds returns a duration attribute in whole seconds, rounded up,
+// or 0 if it is not set.
+func durationSeconds(value types.String) int32 {
+	if value.Null || value.Unknown {
+		return 0
+	}
+
+	// Already checked by the attribute validator.
+	d, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return 0
+	}
+
+	return (int32)(math.Ceil(d.Seconds()))
+}
+
+// newHealthCheckData converts the health check of an imported load balancer,
+// leaving the attributes kubeberth reports no value for null.
+func newHealthCheckData(current *kubeberth.HealthCheck) *healthCheckData {
+	data := &healthCheckData{
+		Protocol:           types.String{Value: current.Protocol},
+		Port:               types.Int64{Null: current.Port == 0, Value: int64(current.Port)},
+		Path:               types.String{Null: current.Path == "", Value: current.Path},
+		Interval:           types.String{Null: true},
+		Timeout:            types.String{Null: true},
+		HealthyThreshold:   types.Int64{Null: current.HealthyThreshold == 0, Value: int64(current.HealthyThreshold)},
+		UnhealthyThreshold: types.Int64{Null: current.UnhealthyThreshold == 0, Value: int64(current.UnhealthyThreshold)},
+	}
+	if current.IntervalSeconds != 0 {
+		data.Interval = types.String{Value: (time.Duration(current.IntervalSeconds) * time.Second).String()}
+	}
+	if current.TimeoutSeconds != 0 {
+		data.Timeout = types.String{Value: (time.Duration(current.TimeoutSeconds) * time.Second).String()}
+	}
+
+	return data
+}
+
+// refreshHealthCheckData returns the health check reported by kubeberth,
+// keeping the configured values that kubeberth reports as equivalent and
+// attributes left to the kubeberth defaults unset.
+func refreshHealthCheckData(data *healthCheckData, current *kubeberth.HealthCheck) *healthCheckData {
+	if data == nil || current == nil {
+		return nil
+	}
+
+	refreshed := *data
+	refreshed.Protocol = types.String{Value: current.Protocol}
+	if !data.Port.Null {
+		refreshed.Port = types.Int64{Value: int64(current.Port)}
+	}
+	if !data.Path.Null {
+		refreshed.Path = types.String{Value: current.Path}
+	}
+	if !data.Interval.Null && durationSeconds(data.Interval) != current.IntervalSeconds {
+		refreshed.Interval = types.String{Value: (time.Duration(current.IntervalSeconds) * time.Second).String()}
+	}
+	if !data.Timeout.Null && durationSeconds(data.Timeout) != current.TimeoutSeconds {
+		refreshed.Timeout = types.String{Value: (time.Duration(current.TimeoutSeconds) * time.Second).String()}
+	}
+	if !data.HealthyThreshold.Null {
+		refreshed.HealthyThreshold = types.Int64{Value: int64(current.HealthyThreshold)}
+	}
+	if !data.UnhealthyThreshold.Null {
+		refreshed.UnhealthyThreshold = types.Int64{Value: int64(current.UnhealthyThreshold)}
+	}
+
+	return &refreshed
+}
+
+// newBackendHealthMap returns whether each backend currently passes its
+// readiness check, keyed by server name.
+func newBackendHealthMap(statuses []kubeberth.BackendStatus) types.Map {
+	m := types.Map{ElemType: types.BoolType, Elems: map[string]attr.Value{}}
+	for _, status := range statuses {
+		m.Elems[status.Server] = types.Bool{Value: status.Ready}
+	}
+
+	return m
+}
+
+// validateLoadBalancerHealthCheck rejects a path on TCP health checks and a
+// timeout longer than the interval.
+func validateLoadBalancerHealthCheck(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var object types.Object
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("health_check"), &object)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || object.Null || object.Unknown {
+		return
+	}
+
+	var healthCheck healthCheckData
+	diags = object.As(ctx, &healthCheck, types.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName("health_check")
+
+	if !healthCheck.Path.Null && healthCheck.Protocol.Value == healthCheckProtocolTCP {
+		diagnostics.AddAttributeError(path.WithAttributeName("path"), "Invalid Attribute Combination",
+			"path can only be set for HTTP and HTTPS health checks.")
+	}
+
+	interval, timeout := durationSeconds(healthCheck.Interval), durationSeconds(healthCheck.Timeout)
+	if interval > 0 && timeout > interval {
+		diagnostics.AddAttributeError(path.WithAttributeName("timeout"), "Invalid Attribute Value",
+			"timeout must not be longer than interval.")
+	}
+}
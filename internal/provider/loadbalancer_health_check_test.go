@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationSeconds(t *testing.T) {
+	tests := map[string]struct {
+		value types.String
+		want  int32
+	}{
+		"null": {
+			value: types.String{Null: true},
+			want:  0,
+		},
+		"unknown": {
+			value: types.String{Unknown: true},
+			want:  0,
+		},
+		"seconds": {
+			value: types.String{Value: "10s"},
+			want:  10,
+		},
+		"minutes": {
+			value: types.String{Value: "2m"},
+			want:  120,
+		},
+		"rounded up": {
+			value: types.String{Value: "1500ms"},
+			want:  2,
+		},
+		"invalid": {
+			value: types.String{Value: "soon"},
+			want:  0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := durationSeconds(test.value); got != test.want {
+				t.Errorf("expected %d, got %d", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewHealthCheckData(t *testing.T) {
+	got := newHealthCheckData(&kubeberth.HealthCheck{
+		Protocol:        healthCheckProtocolHTTP,
+		Path:            "/healthz",
+		IntervalSeconds: 90,
+	})
+	want := &healthCheckData{
+		Protocol:           types.String{Value: healthCheckProtocolHTTP},
+		Port:               types.Int64{Null: true},
+		Path:               types.String{Value: "/healthz"},
+		Interval:           types.String{Value: "1m30s"},
+		Timeout:            types.String{Null: true},
+		HealthyThreshold:   types.Int64{Null: true},
+		UnhealthyThreshold: types.Int64{Null: true},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRefreshHealthCheckData(t *testing.T) {
+	data := &healthCheckData{
+		Protocol:           types.String{Value: healthCheckProtocolHTTP},
+		Port:               types.Int64{Null: true},
+		Path:               types.String{Value: "/healthz"},
+		Interval:           types.String{Value: "1500ms"},
+		Timeout:            types.String{Value: "1s"},
+		HealthyThreshold:   types.Int64{Null: true},
+		UnhealthyThreshold: types.Int64{Value: 3},
+	}
+
+	tests := map[string]struct {
+		data    *healthCheckData
+		current *kubeberth.HealthCheck
+		want    *healthCheckData
+	}{
+		"unset": {
+			current: &kubeberth.HealthCheck{Protocol: healthCheckProtocolTCP},
+			want:    nil,
+		},
+		"removed outside of terraform": {
+			data: data,
+			want: nil,
+		},
+		"unchanged": {
+			data: data,
+			current: &kubeberth.HealthCheck{
+				Protocol:           healthCheckProtocolHTTP,
+				Port:               8080,
+				Path:               "/healthz",
+				IntervalSeconds:    2,
+				TimeoutSeconds:     1,
+				HealthyThreshold:   2,
+				UnhealthyThreshold: 3,
+			},
+			want: data,
+		},
+		"changed": {
+			data: data,
+			current: &kubeberth.HealthCheck{
+				Protocol:           healthCheckProtocolHTTPS,
+				Path:               "/ready",
+				IntervalSeconds:    5,
+				TimeoutSeconds:     1,
+				UnhealthyThreshold: 5,
+			},
+			want: &healthCheckData{
+				Protocol:           types.String{Value: healthCheckProtocolHTTPS},
+				Port:               types.Int64{Null: true},
+				Path:               types.String{Value: "/ready"},
+				Interval:           types.String{Value: "5s"},
+				Timeout:            types.String{Value: "1s"},
+				HealthyThreshold:   types.Int64{Null: true},
+				UnhealthyThreshold: types.Int64{Value: 5},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshHealthCheckData(test.data, test.current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewBackendHealthMap(t *testing.T) {
+	got := newBackendHealthMap([]kubeberth.BackendStatus{
+		{Server: "web-1", Ready: true},
+		{Server: "web-2", Ready: false},
+	})
+	want := types.Map{ElemType: types.BoolType, Elems: map[string]attr.Value{
+		"web-1": types.Bool{Value: true},
+		"web-2": types.Bool{Value: false},
+	}}
+
+	if !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidateLoadBalancerHealthCheck(t *testing.T) {
+	healthCheck := func(protocol string, path string, interval string, timeout string) *healthCheckData {
+		return &healthCheckData{
+			Protocol:           types.String{Value: protocol},
+			Port:               types.Int64{Null: true},
+			Path:               types.String{Null: path == "", Value: path},
+			Interval:           types.String{Null: interval == "", Value: interval},
+			Timeout:            types.String{Null: timeout == "", Value: timeout},
+			HealthyThreshold:   types.Int64{Null: true},
+			UnhealthyThreshold: types.Int64{Null: true},
+		}
+	}
+
+	tests := map[string]struct {
+		healthCheck *healthCheckData
+		wantErrors  int
+	}{
+		"unset": {},
+		"tcp": {
+			healthCheck: healthCheck(healthCheckProtocolTCP, "", "10s", "1s"),
+		},
+		"http with path": {
+			healthCheck: healthCheck(healthCheckProtocolHTTP, "/healthz", "", ""),
+		},
+		"tcp with path": {
+			healthCheck: healthCheck(healthCheckProtocolTCP, "/healthz", "", ""),
+			wantErrors:  1,
+		},
+		"timeout longer than interval": {
+			healthCheck: healthCheck(healthCheckProtocolHTTP, "", "5s", "10s"),
+			wantErrors:  1,
+		},
+		"timeout rounded up to interval": {
+			healthCheck: healthCheck(healthCheckProtocolHTTP, "", "1s", "500ms"),
+		},
+		"timeout without interval": {
+			healthCheck: healthCheck(healthCheckProtocolHTTP, "", "", "10s"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{}
+			if test.healthCheck != nil {
+				attributes["health_check"] = test.healthCheck
+			}
+			config := testConfig(t, loadbalancerResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateLoadBalancerHealthCheck(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kubeberth/kubeberth-go"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -28,14 +31,14 @@ func (t loadbalancerResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 		// This description is used by the documentation generator and the language server..
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
 				Required:            true,
 			},
 			"backends": {
-				MarkdownDescription: "backends",
+				MarkdownDescription: "backends. Conflicts with `backend_selector`.",
 				Optional:            true,
 				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
 					"server": {
@@ -44,6 +47,16 @@ func (t loadbalancerResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 					},
 				}, tfsdk.ListNestedAttributesOptions{}),
 			},
+			"backend_selector": {
+				MarkdownDescription: "Send traffic to the servers carrying all of these labels, e.g. the `labels` of a group of `kubeberth_server` resources. Conflicts with `backends`.",
+				Type:                types.MapType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"selected_backends": {
+				MarkdownDescription: "Names of the servers the load balancer currently sends traffic to: the `backends`, or the servers matching `backend_selector`.",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
 			"ports": {
 				MarkdownDescription: "ports",
 				Required:            true,
@@ -64,9 +77,83 @@ func (t loadbalancerResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 						Type:     types.Int64Type,
 						Required: true,
 					},
+					"node_port": {
+						MarkdownDescription: "Port to expose on every node when `type` is `NodePort` or `LoadBalancer`. Must lie in the node port range of the cluster, `30000-32767` by default. Allocated by the cluster if not set.",
+						Type:                types.Int64Type,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64Between(1, 65535),
+						},
+					},
 				}, tfsdk.ListNestedAttributesOptions{}),
 			},
-		},
+			"type": {
+				MarkdownDescription: "How the load balancer is exposed: `LoadBalancer` (the default) on an external IP, `NodePort` on a port of every node, or `ClusterIP` on an address only reachable inside the cluster.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(string(corev1.ServiceTypeLoadBalancer), string(corev1.ServiceTypeNodePort), string(corev1.ServiceTypeClusterIP)),
+				},
+			},
+			"health_check": {
+				MarkdownDescription: "Only send traffic to backends passing this check, e.g. to skip a rebooting server.",
+				Optional:            true,
+				Attributes:          tfsdk.SingleNestedAttributes(healthCheckAttributes()),
+			},
+			"backend_health": {
+				MarkdownDescription: "Whether each backend currently passes its readiness check, keyed by server name.",
+				Type:                types.MapType{ElemType: types.BoolType},
+				Computed:            true,
+			},
+			"cluster_ip": {
+				MarkdownDescription: "Address of the load balancer inside the cluster.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"session_affinity": {
+				MarkdownDescription: "Session affinity: `None` or `ClientIP`, which sends every connection from a client to the same backend.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(string(corev1.ServiceAffinityNone), string(corev1.ServiceAffinityClientIP)),
+				},
+			},
+			"session_affinity_timeout": {
+				MarkdownDescription: "How long, in seconds, a client sticks to its backend when `session_affinity` is `ClientIP`. Defaults to 10800.",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					int64Between(1, 86400),
+				},
+			},
+			"external_traffic_policy": {
+				MarkdownDescription: "External traffic policy: `Cluster`, or `Local` to only route to backends on the receiving node and keep the client IP.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(string(corev1.ServiceExternalTrafficPolicyTypeCluster), string(corev1.ServiceExternalTrafficPolicyTypeLocal)),
+				},
+			},
+			"load_balancer_source_ranges": {
+				MarkdownDescription: "Only accept traffic from these CIDRs, e.g. `[\"192.0.2.0/24\"]`.",
+				Type:                types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					eachString(cidrString()),
+				},
+			},
+			"load_balancer_ip": {
+				MarkdownDescription: "IP address to request for the load balancer, if the cluster supports choosing it.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					ipAddress(),
+				},
+			},
+		})),
 	}, nil
 }
 
@@ -87,12 +174,33 @@ type portData struct {
 	Protocol   types.String `tfsdk:"protocol"`
 	Port       types.Int64  `tfsdk:"port"`
 	TargetPort types.Int64  `tfsdk:"target_port"`
+	NodePort   types.Int64  `tfsdk:"node_port"`
 }
 
 type loadbalancerResourceData struct {
 	Name     types.String      `tfsdk:"name"`
 	Backends []destinationData `tfsdk:"backends"`
 	Ports    []portData        `tfsdk:"ports"`
+
+	BackendSelector  map[string]string `tfsdk:"backend_selector"`
+	SelectedBackends types.List        `tfsdk:"selected_backends"`
+
+	SessionAffinity          types.String   `tfsdk:"session_affinity"`
+	SessionAffinityTimeout   types.Int64    `tfsdk:"session_affinity_timeout"`
+	ExternalTrafficPolicy    types.String   `tfsdk:"external_traffic_policy"`
+	LoadBalancerSourceRanges []types.String `tfsdk:"load_balancer_source_ranges"`
+	LoadBalancerIP           types.String   `tfsdk:"load_balancer_ip"`
+
+	Type      types.String `tfsdk:"type"`
+	ClusterIP types.String `tfsdk:"cluster_ip"`
+
+	HealthCheck   *healthCheckData `tfsdk:"health_check"`
+	BackendHealth types.Map        `tfsdk:"backend_health"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
 }
 
 type loadbalancerResource struct {
@@ -113,6 +221,7 @@ func newRequestLoadBalancer(data *loadbalancerResourceData) *kubeberth.RequestLo
 			Protocol:   (corev1.Protocol)(port.Protocol.Value),
 			Port:       (int32)(port.Port.Value),
 			TargetPort: intstr.FromInt((int)(port.TargetPort.Value)),
+			NodePort:   (int32)(port.NodePort.Value),
 		})
 	}
 
@@ -120,15 +229,224 @@ func newRequestLoadBalancer(data *loadbalancerResourceData) *kubeberth.RequestLo
 		Name:     data.Name.Value,
 		Backends: backends,
 		Ports:    ports,
+
+		BackendSelector: data.BackendSelector,
+
+		SessionAffinity:       (corev1.ServiceAffinity)(data.SessionAffinity.Value),
+		ExternalTrafficPolicy: (corev1.ServiceExternalTrafficPolicyType)(data.ExternalTrafficPolicy.Value),
+		LoadBalancerIP:        data.LoadBalancerIP.Value,
+
+		Type: (corev1.ServiceType)(data.Type.Value),
+
+		HealthCheck: newHealthCheck(data.HealthCheck),
+	}
+
+	if !data.SessionAffinityTimeout.Null {
+		timeout := (int32)(data.SessionAffinityTimeout.Value)
+		loadbalancer.SessionAffinityTimeoutSeconds = &timeout
+	}
+
+	for _, sourceRange := range data.LoadBalancerSourceRanges {
+		loadbalancer.LoadBalancerSourceRanges = append(loadbalancer.LoadBalancerSourceRanges, sourceRange.Value)
 	}
 
 	return loadbalancer
 }
 
+// selectedBackends returns the names of the servers the load balancer sends
+// traffic to, looking up the servers matching backend_selector if it is set.
+// Failing to list the servers only warns, as the load balancer itself has
+// been applied; the list is refreshed on the next read.
+func (r loadbalancerResource) selectedBackends(ctx context.Context, data *loadbalancerResourceData, diagnostics *diag.Diagnostics) types.List {
+	selected := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+
+	if data.BackendSelector == nil {
+		for _, destination := range data.Backends {
+			selected.Elems = append(selected.Elems, destination.Server)
+		}
+		return selected
+	}
+
+	responseServers, err := r.provider.namespacedClient(data.Namespace).GetAllServers(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("servers: %+v\n", responseServers))
+	if err != nil {
+		diagnostics.AddWarning("Client Error", fmt.Sprintf("Unable to list servers matching backend_selector, got error: %s", err))
+		return types.List{ElemType: types.StringType, Null: true}
+	}
+
+	filter := &listFilter{labels: data.BackendSelector}
+
+	names := []string{}
+	for _, server := range responseServers {
+		if filter.match(server.Name, server.Labels) {
+			names = append(names, server.Name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		selected.Elems = append(selected.Elems, types.String{Value: name})
+	}
+
+	return selected
+}
+
+// refreshPorts returns the ports kubeberth reports. node_port is only
+// refreshed where it is set, as the cluster allocates one whether or not it
+// is configured.
+func refreshPorts(ports []portData, current []kubeberth.Port) []portData {
+	refreshed := []portData{}
+	for i := range current {
+		port := portData{
+			Name:       types.String{Value: current[i].Name},
+			Protocol:   types.String{Value: string(current[i].Protocol)},
+			Port:       types.Int64{Value: int64(current[i].Port)},
+			TargetPort: types.Int64{Value: int64(current[i].TargetPort.IntValue())},
+			NodePort:   types.Int64{Null: true},
+		}
+		if i < len(ports) && !ports[i].NodePort.Null {
+			port.NodePort = types.Int64{Value: int64(current[i].NodePort)}
+		}
+		refreshed = append(refreshed, port)
+	}
+
+	return refreshed
+}
+
+// refreshSourceRanges returns the source ranges reported by kubeberth, keeping
+// the configured notation of ranges that denote the same network, e.g.
+// `192.0.2.1/24` for `192.0.2.0/24`.
+func refreshSourceRanges(managed []types.String, current []string) []types.String {
+	refreshed := []types.String{}
+	for i, sourceRange := range current {
+		if i < len(managed) && cidrsEqual(managed[i].Value, sourceRange) {
+			refreshed = append(refreshed, managed[i])
+			continue
+		}
+		refreshed = append(refreshed, types.String{Value: sourceRange})
+	}
+
+	return refreshed
+}
+
+func cidrsEqual(a, b string) bool {
+	_, networkA, errA := net.ParseCIDR(a)
+	_, networkB, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return networkA.String() == networkB.String()
+}
+
+func (r loadbalancerResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	validateLoadBalancerBackends(ctx, req.Config, &resp.Diagnostics)
+	validateLoadBalancerSessionAffinity(ctx, req.Config, &resp.Diagnostics)
+	validateLoadBalancerType(ctx, req.Config, &resp.Diagnostics)
+	validateLoadBalancerHealthCheck(ctx, req.Config, &resp.Diagnostics)
+}
+
+// validateLoadBalancerType rejects attributes that have no effect with the
+// configured type: node ports on a ClusterIP load balancer, and the external
+// options on anything but a LoadBalancer.
+func validateLoadBalancerType(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var serviceType types.String
+	var ports []portData
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("type"), &serviceType)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("ports"), &ports)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || serviceType.Null || serviceType.Unknown || serviceType.Value == string(corev1.ServiceTypeLoadBalancer) {
+		return
+	}
+
+	if serviceType.Value == string(corev1.ServiceTypeClusterIP) {
+		for i, port := range ports {
+			if !port.NodePort.Null {
+				diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("ports").WithElementKeyInt(i).WithAttributeName("node_port"), "Invalid Attribute Combination",
+					"node_port cannot be set when type is \"ClusterIP\".")
+			}
+		}
+	}
+
+	var sourceRanges types.List
+	var loadBalancerIP, externalTrafficPolicy types.String
+	diags = config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("load_balancer_source_ranges"), &sourceRanges)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("load_balancer_ip"), &loadBalancerIP)...)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("external_traffic_policy"), &externalTrafficPolicy)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	set := map[string]bool{
+		"load_balancer_source_ranges": !sourceRanges.Null,
+		"load_balancer_ip":            !loadBalancerIP.Null,
+		// NodePort services route external traffic too.
+		"external_traffic_policy": !externalTrafficPolicy.Null && serviceType.Value == string(corev1.ServiceTypeClusterIP),
+	}
+
+	for _, name := range []string{"load_balancer_source_ranges", "load_balancer_ip", "external_traffic_policy"} {
+		if set[name] {
+			diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName(name), "Invalid Attribute Combination",
+				fmt.Sprintf("%s cannot be set when type is %q.", name, serviceType.Value))
+		}
+	}
+}
+
+// validateLoadBalancerBackends rejects backends together with
+// backend_selector, and an empty selector.
+func validateLoadBalancerBackends(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var backends types.List
+	var backendSelector types.Map
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("backends"), &backends)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("backend_selector"), &backendSelector)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || backendSelector.Null {
+		return
+	}
+
+	if !backends.Null {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("backend_selector"), "Conflicting Attributes",
+			"Only one of backends and backend_selector can be set.")
+	}
+
+	if !backendSelector.Unknown && len(backendSelector.Elems) == 0 {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("backend_selector"), "Invalid Attribute Value",
+			"backend_selector must contain at least one label; an empty selector would match every server.")
+	}
+}
+
+// validateLoadBalancerSessionAffinity checks that a session affinity timeout
+// is only set together with ClientIP affinity.
+func validateLoadBalancerSessionAffinity(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var sessionAffinity types.String
+	var timeout types.Int64
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("session_affinity"), &sessionAffinity)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("session_affinity_timeout"), &timeout)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || timeout.Null || sessionAffinity.Unknown {
+		return
+	}
+
+	if sessionAffinity.Value != string(corev1.ServiceAffinityClientIP) {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("session_affinity_timeout"), "Invalid Attribute Combination",
+			"session_affinity_timeout requires session_affinity = \"ClientIP\".")
+	}
+}
+
+func (r loadbalancerResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+}
+
 func (r loadbalancerResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data loadbalancerResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -144,13 +462,23 @@ func (r loadbalancerResource) Create(ctx context.Context, req tfsdk.CreateResour
 	// }
 
 	requestLoadBalancer := newRequestLoadBalancer(&data)
-	responseLoadBalancer, err := r.provider.client.CreateLoadBalancer(ctx, requestLoadBalancer)
+	requestLoadBalancer.Labels, requestLoadBalancer.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	requestLoadBalancer.Namespace = data.Namespace.Value
+
+	responseLoadBalancer, err := r.provider.namespacedClient(data.Namespace).CreateLoadBalancer(ctx, requestLoadBalancer)
 	tflog.Trace(ctx, fmt.Sprintf("loadbalancer: %+v\n", responseLoadBalancer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create loadbalancer, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, responseLoadBalancer.Namespace)
+
+	data.SelectedBackends = r.selectedBackends(ctx, &data, &resp.Diagnostics)
+	data.ClusterIP = types.String{Value: responseLoadBalancer.ClusterIP}
+	data.BackendHealth = newBackendHealthMap(responseLoadBalancer.BackendStatuses)
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -183,13 +511,66 @@ func (r loadbalancerResource) Read(ctx context.Context, req tfsdk.ReadResourceRe
 	//     return
 	// }
 
-	responseLoadBalancer, err := r.provider.client.GetLoadBalancer(ctx, data.Name.Value)
+	responseLoadBalancer, err := r.provider.namespacedClient(data.Namespace).GetLoadBalancer(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("loadbalancer: %+v\n", responseLoadBalancer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read loadbalancer, got error: %s", err))
 		return
 	}
 
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, responseLoadBalancer.Labels, responseLoadBalancer.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, responseLoadBalancer.Namespace)
+
+	// ports is required, so it is only null right after ImportState. Optional
+	// attributes are then set from whatever kubeberth reports, leaving those
+	// at their default null.
+	imported := data.Ports == nil
+
+	data.Ports = refreshPorts(data.Ports, responseLoadBalancer.Ports)
+
+	if data.Backends != nil || (imported && len(responseLoadBalancer.BackendSelector) == 0 && len(responseLoadBalancer.Backends) > 0) {
+		data.Backends = []destinationData{}
+		for _, destination := range responseLoadBalancer.Backends {
+			data.Backends = append(data.Backends, destinationData{Server: types.String{Value: destination.Server}})
+		}
+	}
+	if (data.BackendSelector != nil && responseLoadBalancer.BackendSelector != nil) || (imported && len(responseLoadBalancer.BackendSelector) > 0) {
+		data.BackendSelector = responseLoadBalancer.BackendSelector
+	}
+
+	data.SelectedBackends = r.selectedBackends(ctx, &data, &resp.Diagnostics)
+
+	sessionAffinity := responseLoadBalancer.SessionAffinity
+	if (!data.SessionAffinity.Null || (imported && sessionAffinity != corev1.ServiceAffinityNone)) && sessionAffinity != "" {
+		data.SessionAffinity = types.String{Value: string(sessionAffinity)}
+	}
+	if (!data.SessionAffinityTimeout.Null || (imported && sessionAffinity == corev1.ServiceAffinityClientIP)) && responseLoadBalancer.SessionAffinityTimeoutSeconds != nil {
+		data.SessionAffinityTimeout = types.Int64{Value: int64(*responseLoadBalancer.SessionAffinityTimeoutSeconds)}
+	}
+	externalTrafficPolicy := responseLoadBalancer.ExternalTrafficPolicy
+	if (!data.ExternalTrafficPolicy.Null || (imported && externalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeCluster)) && externalTrafficPolicy != "" {
+		data.ExternalTrafficPolicy = types.String{Value: string(externalTrafficPolicy)}
+	}
+	if data.LoadBalancerSourceRanges != nil || (imported && len(responseLoadBalancer.LoadBalancerSourceRanges) > 0) {
+		data.LoadBalancerSourceRanges = refreshSourceRanges(data.LoadBalancerSourceRanges, responseLoadBalancer.LoadBalancerSourceRanges)
+	}
+	if (!data.LoadBalancerIP.Null || imported) && responseLoadBalancer.LoadBalancerIP != "" && !net.ParseIP(data.LoadBalancerIP.Value).Equal(net.ParseIP(responseLoadBalancer.LoadBalancerIP)) {
+		data.LoadBalancerIP = types.String{Value: responseLoadBalancer.LoadBalancerIP}
+	}
+
+	serviceType := responseLoadBalancer.Type
+	if (!data.Type.Null || (imported && serviceType != corev1.ServiceTypeLoadBalancer)) && serviceType != "" {
+		data.Type = types.String{Value: string(serviceType)}
+	}
+	data.ClusterIP = types.String{Value: responseLoadBalancer.ClusterIP}
+
+	if imported && responseLoadBalancer.HealthCheck != nil {
+		data.HealthCheck = newHealthCheckData(responseLoadBalancer.HealthCheck)
+	} else {
+		data.HealthCheck = refreshHealthCheckData(data.HealthCheck, responseLoadBalancer.HealthCheck)
+	}
+	data.BackendHealth = newBackendHealthMap(responseLoadBalancer.BackendStatuses)
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -215,13 +596,22 @@ func (r loadbalancerResource) Update(ctx context.Context, req tfsdk.UpdateResour
 	// }
 
 	requestLoadBalancer := newRequestLoadBalancer(&data)
-	responseLoadBalancer, err := r.provider.client.UpdateLoadBalancer(ctx, data.Name.Value, requestLoadBalancer)
+	requestLoadBalancer.Labels, requestLoadBalancer.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	requestLoadBalancer.Namespace = data.Namespace.Value
+
+	responseLoadBalancer, err := r.provider.namespacedClient(data.Namespace).UpdateLoadBalancer(ctx, data.Name.Value, requestLoadBalancer)
 	tflog.Trace(ctx, fmt.Sprintf("loadbalancer: %+v\n", responseLoadBalancer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update loadbalancer, got error: %s", err))
 		return
 	}
 
+	data.SelectedBackends = r.selectedBackends(ctx, &data, &resp.Diagnostics)
+	if data.ClusterIP.Unknown {
+		data.ClusterIP = types.String{Value: responseLoadBalancer.ClusterIP}
+	}
+	data.BackendHealth = newBackendHealthMap(responseLoadBalancer.BackendStatuses)
+
 	tflog.Trace(ctx, "updated a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -246,7 +636,7 @@ func (r loadbalancerResource) Delete(ctx context.Context, req tfsdk.DeleteResour
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteLoadBalancer(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteLoadBalancer(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("loadbalancer: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete loadbalancer, got error: %s", err))
@@ -255,5 +645,5 @@ func (r loadbalancerResource) Delete(ctx context.Context, req tfsdk.DeleteResour
 }
 
 func (r loadbalancerResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
@@ -1,41 +1,46 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccLoadBalancerResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccLoadBalancerResourceConfig(80),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("scaffolding_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "name", "terraform-acc-loadbalancer"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "ports.0.port", "80"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "scaffolding_example.test",
+				ResourceName:      "kubeberth_loadbalancer.test",
 				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_loadbalancer.test", "name"),
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccLoadBalancerResourceConfig(8080),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "ports.0.port", "8080"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -43,10 +48,493 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func testAccLoadBalancerResourceConfig(port int) string {
 	return fmt.Sprintf(`
-resource "scaffolding_example" "test" {
-  configurable_attribute = %[1]q
+resource "kubeberth_loadbalancer" "test" {
+  name  = "terraform-acc-loadbalancer"
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = %[1]d
+      target_port = 80
+    },
+  ]
+}
+`, port)
+}
+
+func TestAccLoadBalancerResourceBackendSelector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoadBalancerResourceBackendSelectorConfig("web"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "backend_selector.role", "web"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "selected_backends.#", "1"),
+					resource.TestCheckResourceAttrPair("kubeberth_loadbalancer.test", "selected_backends.0", "kubeberth_server.test", "name"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccLoadBalancerResourceBackendSelectorConfig("db"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "backend_selector.role", "db"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "selected_backends.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLoadBalancerResourceBackendSelectorConfig(role string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-loadbalancer-selector"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-loadbalancer-selector"
+
+  labels = {
+    role = "web"
+  }
+}
+
+resource "kubeberth_loadbalancer" "test" {
+  name = "terraform-acc-loadbalancer-selector"
+  backend_selector = {
+    role = %[1]q
+  }
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+    },
+  ]
+
+  depends_on = [kubeberth_server.test]
+}
+`, role)
+}
+
+func TestValidateLoadBalancerBackends(t *testing.T) {
+	tests := map[string]struct {
+		attributes map[string]interface{}
+		wantErrors int
+	}{
+		"unset": {
+			attributes: map[string]interface{}{},
+		},
+		"backends": {
+			attributes: map[string]interface{}{
+				"backends": []destinationData{{Server: types.String{Value: "web-1"}}},
+			},
+		},
+		"backend_selector": {
+			attributes: map[string]interface{}{
+				"backend_selector": map[string]string{"role": "web"},
+			},
+		},
+		"both": {
+			attributes: map[string]interface{}{
+				"backends":         []destinationData{{Server: types.String{Value: "web-1"}}},
+				"backend_selector": map[string]string{"role": "web"},
+			},
+			wantErrors: 1,
+		},
+		"empty backend_selector": {
+			attributes: map[string]interface{}{
+				"backend_selector": map[string]string{},
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, loadbalancerResourceType{}, test.attributes)
+
+			var diags diag.Diagnostics
+			validateLoadBalancerBackends(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestAccLoadBalancerResourceTrafficOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoadBalancerResourceTrafficOptionsConfig(3600, "192.0.2.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "session_affinity", "ClientIP"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "session_affinity_timeout", "3600"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "external_traffic_policy", "Local"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "load_balancer_source_ranges.0", "192.0.2.0/24"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccLoadBalancerResourceTrafficOptionsConfig(60, "198.51.100.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "session_affinity_timeout", "60"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "load_balancer_source_ranges.0", "198.51.100.0/24"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLoadBalancerResourceTrafficOptionsConfig(timeout int, sourceRange string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_loadbalancer" "test" {
+  name  = "terraform-acc-loadbalancer-traffic"
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+    },
+  ]
+
+  session_affinity            = "ClientIP"
+  session_affinity_timeout    = %[1]d
+  external_traffic_policy     = "Local"
+  load_balancer_source_ranges = [%[2]q]
+}
+`, timeout, sourceRange)
+}
+
+func TestValidateLoadBalancerSessionAffinity(t *testing.T) {
+	tests := map[string]struct {
+		attributes map[string]interface{}
+		wantErrors int
+	}{
+		"unset": {
+			attributes: map[string]interface{}{},
+		},
+		"client ip with timeout": {
+			attributes: map[string]interface{}{
+				"session_affinity":         "ClientIP",
+				"session_affinity_timeout": int64(60),
+			},
+		},
+		"timeout without affinity": {
+			attributes: map[string]interface{}{
+				"session_affinity_timeout": int64(60),
+			},
+			wantErrors: 1,
+		},
+		"timeout with none": {
+			attributes: map[string]interface{}{
+				"session_affinity":         "None",
+				"session_affinity_timeout": int64(60),
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, loadbalancerResourceType{}, test.attributes)
+
+			var diags diag.Diagnostics
+			validateLoadBalancerSessionAffinity(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestRefreshSourceRanges(t *testing.T) {
+	tests := map[string]struct {
+		managed []types.String
+		current []string
+		want    []types.String
+	}{
+		"unchanged": {
+			managed: []types.String{{Value: "192.0.2.0/24"}},
+			current: []string{"192.0.2.0/24"},
+			want:    []types.String{{Value: "192.0.2.0/24"}},
+		},
+		"same network in other notation": {
+			managed: []types.String{{Value: "192.0.2.1/24"}},
+			current: []string{"192.0.2.0/24"},
+			want:    []types.String{{Value: "192.0.2.1/24"}},
+		},
+		"changed": {
+			managed: []types.String{{Value: "192.0.2.0/24"}},
+			current: []string{"198.51.100.0/24"},
+			want:    []types.String{{Value: "198.51.100.0/24"}},
+		},
+		"added outside of terraform": {
+			managed: []types.String{{Value: "192.0.2.0/24"}},
+			current: []string{"192.0.2.0/24", "2001:db8::/32"},
+			want:    []types.String{{Value: "192.0.2.0/24"}, {Value: "2001:db8::/32"}},
+		},
+		"removed": {
+			managed: []types.String{{Value: "192.0.2.0/24"}},
+			current: nil,
+			want:    []types.String{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshSourceRanges(test.managed, test.current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAccLoadBalancerResourceType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoadBalancerResourceTypeConfig("NodePort", "node_port = 30080"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "type", "NodePort"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "ports.0.node_port", "30080"),
+					resource.TestCheckResourceAttrSet("kubeberth_loadbalancer.test", "cluster_ip"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccLoadBalancerResourceTypeConfig("ClusterIP", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "type", "ClusterIP"),
+					resource.TestCheckNoResourceAttr("kubeberth_loadbalancer.test", "ports.0.node_port"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLoadBalancerResourceTypeConfig(serviceType string, nodePort string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_loadbalancer" "test" {
+  name  = "terraform-acc-loadbalancer-type"
+  type  = %[1]q
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+      %[2]s
+    },
+  ]
+}
+`, serviceType, nodePort)
+}
+
+func TestValidateLoadBalancerType(t *testing.T) {
+	port := func(nodePort int64) []portData {
+		return []portData{{
+			Name:       types.String{Value: "http"},
+			Protocol:   types.String{Value: "TCP"},
+			Port:       types.Int64{Value: 80},
+			TargetPort: types.Int64{Value: 80},
+			NodePort:   types.Int64{Null: nodePort == 0, Value: nodePort},
+		}}
+	}
+
+	tests := map[string]struct {
+		attributes map[string]interface{}
+		wantErrors int
+	}{
+		"default type with external options": {
+			attributes: map[string]interface{}{
+				"ports":                       port(30080),
+				"load_balancer_ip":            "192.0.2.10",
+				"load_balancer_source_ranges": []string{"192.0.2.0/24"},
+				"external_traffic_policy":     "Local",
+			},
+		},
+		"node port": {
+			attributes: map[string]interface{}{
+				"type":                    "NodePort",
+				"ports":                   port(30080),
+				"external_traffic_policy": "Local",
+			},
+		},
+		"node port with load balancer options": {
+			attributes: map[string]interface{}{
+				"type":                        "NodePort",
+				"ports":                       port(0),
+				"load_balancer_ip":            "192.0.2.10",
+				"load_balancer_source_ranges": []string{"192.0.2.0/24"},
+			},
+			wantErrors: 2,
+		},
+		"cluster ip": {
+			attributes: map[string]interface{}{
+				"type":  "ClusterIP",
+				"ports": port(0),
+			},
+		},
+		"cluster ip with node port": {
+			attributes: map[string]interface{}{
+				"type":  "ClusterIP",
+				"ports": port(30080),
+			},
+			wantErrors: 1,
+		},
+		"cluster ip with external traffic policy": {
+			attributes: map[string]interface{}{
+				"type":                    "ClusterIP",
+				"ports":                   port(0),
+				"external_traffic_policy": "Local",
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, loadbalancerResourceType{}, test.attributes)
+
+			var diags diag.Diagnostics
+			validateLoadBalancerType(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestRefreshPorts(t *testing.T) {
+	port := func(nodePort int64) portData {
+		return portData{
+			Name:       types.String{Value: "http"},
+			Protocol:   types.String{Value: "TCP"},
+			Port:       types.Int64{Value: 80},
+			TargetPort: types.Int64{Value: 8080},
+			NodePort:   types.Int64{Null: nodePort == 0, Value: nodePort},
+		}
+	}
+	current := []kubeberth.Port{{
+		Name:       "http",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       80,
+		TargetPort: intstr.FromInt(8080),
+		NodePort:   31234,
+	}}
+
+	tests := map[string]struct {
+		ports []portData
+		want  []portData
+	}{
+		"allocated node port": {
+			ports: []portData{port(0)},
+			want:  []portData{port(0)},
+		},
+		"configured node port": {
+			ports: []portData{port(30080)},
+			want:  []portData{port(31234)},
+		},
+		"imported": {
+			ports: nil,
+			want:  []portData{port(0)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshPorts(test.ports, current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAccLoadBalancerResourceHealthCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoadBalancerResourceHealthCheckConfig("/healthz"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.protocol", "HTTP"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.path", "/healthz"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.interval", "10s"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.timeout", "1s"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.unhealthy_threshold", "3"),
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "backend_health.%", "1"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccLoadBalancerResourceHealthCheckConfig("/ready"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_loadbalancer.test", "health_check.path", "/ready"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLoadBalancerResourceHealthCheckConfig(path string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-loadbalancer-health"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-loadbalancer-health"
+}
+
+resource "kubeberth_loadbalancer" "test" {
+  name     = "terraform-acc-loadbalancer-health"
+  backends = [
+    {
+      server = kubeberth_server.test.name
+    },
+  ]
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+    },
+  ]
+
+  health_check = {
+    protocol            = "HTTP"
+    path                = %[1]q
+    interval            = "10s"
+    timeout             = "1s"
+    unhealthy_threshold = 3
+  }
 }
-`, configurableAttribute)
+`, path)
 }
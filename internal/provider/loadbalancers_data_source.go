@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = loadbalancersDataSourceType{}
+var _ tfsdk.DataSource = loadbalancersDataSource{}
+
+type loadbalancersDataSourceType struct{}
+
+func (t loadbalancersDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["state"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return loadbalancers in this state.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["backend"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return loadbalancers forwarding to this server, whether it is listed in `backends` or matches `backend_selector`.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["loadbalancers"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching loadbalancers, sorted by name.",
+		Computed:            true,
+		Attributes:          tfsdk.ListNestedAttributes(loadbalancerAttributes(), tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth loadbalancers.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+// loadbalancerAttributes returns the computed attributes describing a loadbalancer.
+func loadbalancerAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name": {
+			MarkdownDescription: "name",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"state": {
+			MarkdownDescription: "state",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"external_ip": {
+			MarkdownDescription: "external_ip",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"backends": {
+			MarkdownDescription: "backends",
+			Computed:            true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"server": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+		},
+		"backend_selector": {
+			MarkdownDescription: "Labels of the servers the loadbalancer sends traffic to, if it selects its backends by label.",
+			Type:                types.MapType{ElemType: types.StringType},
+			Computed:            true,
+		},
+		"ports": {
+			MarkdownDescription: "ports",
+			Computed:            true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"protocol": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"port": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+				"target_port": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+				"node_port": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+		},
+		"session_affinity": {
+			MarkdownDescription: "session_affinity",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"session_affinity_timeout": {
+			MarkdownDescription: "session_affinity_timeout",
+			Type:                types.Int64Type,
+			Computed:            true,
+		},
+		"external_traffic_policy": {
+			MarkdownDescription: "external_traffic_policy",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"load_balancer_source_ranges": {
+			MarkdownDescription: "load_balancer_source_ranges",
+			Type:                types.ListType{ElemType: types.StringType},
+			Computed:            true,
+		},
+		"load_balancer_ip": {
+			MarkdownDescription: "load_balancer_ip",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"type": {
+			MarkdownDescription: "type",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"cluster_ip": {
+			MarkdownDescription: "cluster_ip",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"backend_health": {
+			MarkdownDescription: "Whether each backend currently passes its readiness check, keyed by server name.",
+			Type:                types.MapType{ElemType: types.BoolType},
+			Computed:            true,
+		},
+	}
+}
+
+func (t loadbalancersDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return loadbalancersDataSource{
+		provider: provider,
+	}, diags
+}
+
+type loadbalancerDataSourceData struct {
+	Name       types.String      `tfsdk:"name"`
+	State      types.String      `tfsdk:"state"`
+	ExternalIP types.String      `tfsdk:"external_ip"`
+	Backends   []destinationData `tfsdk:"backends"`
+	Ports      []portData        `tfsdk:"ports"`
+
+	BackendSelector map[string]string `tfsdk:"backend_selector"`
+
+	SessionAffinity          types.String `tfsdk:"session_affinity"`
+	SessionAffinityTimeout   types.Int64  `tfsdk:"session_affinity_timeout"`
+	ExternalTrafficPolicy    types.String `tfsdk:"external_traffic_policy"`
+	LoadBalancerSourceRanges []string     `tfsdk:"load_balancer_source_ranges"`
+	LoadBalancerIP           types.String `tfsdk:"load_balancer_ip"`
+
+	Type      types.String `tfsdk:"type"`
+	ClusterIP types.String `tfsdk:"cluster_ip"`
+
+	BackendHealth types.Map `tfsdk:"backend_health"`
+}
+
+func newLoadBalancerDataSourceData(loadbalancer *kubeberth.ResponseLoadBalancer) *loadbalancerDataSourceData {
+	data := &loadbalancerDataSourceData{
+		Name:       types.String{Value: loadbalancer.Name},
+		State:      types.String{Value: loadbalancer.State},
+		ExternalIP: types.String{Value: loadbalancer.ExternalIP},
+		Backends:   []destinationData{},
+		Ports:      []portData{},
+
+		BackendSelector: loadbalancer.BackendSelector,
+
+		SessionAffinity:          types.String{Value: string(loadbalancer.SessionAffinity)},
+		SessionAffinityTimeout:   types.Int64{Null: loadbalancer.SessionAffinityTimeoutSeconds == nil},
+		ExternalTrafficPolicy:    types.String{Value: string(loadbalancer.ExternalTrafficPolicy)},
+		LoadBalancerSourceRanges: loadbalancer.LoadBalancerSourceRanges,
+		LoadBalancerIP:           types.String{Value: loadbalancer.LoadBalancerIP},
+
+		Type:      types.String{Value: string(loadbalancer.Type)},
+		ClusterIP: types.String{Value: loadbalancer.ClusterIP},
+
+		BackendHealth: newBackendHealthMap(loadbalancer.BackendStatuses),
+	}
+
+	if loadbalancer.SessionAffinityTimeoutSeconds != nil {
+		data.SessionAffinityTimeout.Value = int64(*loadbalancer.SessionAffinityTimeoutSeconds)
+	}
+
+	for _, destination := range loadbalancer.Backends {
+		data.Backends = append(data.Backends, destinationData{Server: types.String{Value: destination.Server}})
+	}
+
+	for _, port := range loadbalancer.Ports {
+		data.Ports = append(data.Ports, portData{
+			Name:       types.String{Value: port.Name},
+			Protocol:   types.String{Value: string(port.Protocol)},
+			Port:       types.Int64{Value: int64(port.Port)},
+			TargetPort: types.Int64{Value: int64(port.TargetPort.IntValue())},
+			NodePort:   types.Int64{Value: int64(port.NodePort)},
+		})
+	}
+
+	return data
+}
+
+type loadbalancersDataSourceData struct {
+	NameRegex     types.String                 `tfsdk:"name_regex"`
+	Labels        map[string]string            `tfsdk:"labels"`
+	State         types.String                 `tfsdk:"state"`
+	Backend       types.String                 `tfsdk:"backend"`
+	LoadBalancers []loadbalancerDataSourceData `tfsdk:"loadbalancers"`
+}
+
+// match reports whether loadbalancer passes the filters of the data source.
+// backendLabels are the labels of the backend server, nil if it does not
+// exist.
+func (data *loadbalancersDataSourceData) match(filter *listFilter, loadbalancer *kubeberth.ResponseLoadBalancer, backendLabels map[string]string) bool {
+	if !filter.match(loadbalancer.Name, loadbalancer.Labels) {
+		return false
+	}
+	if !data.State.Null && loadbalancer.State != data.State.Value {
+		return false
+	}
+	if !data.Backend.Null && !hasBackend(loadbalancer, data.Backend.Value, backendLabels) {
+		return false
+	}
+
+	return true
+}
+
+type loadbalancersDataSource struct {
+	provider provider
+}
+
+// hasBackend reports whether loadbalancer forwards to the named server, either
+// because it is listed in its backends or because labels, the labels of the
+// server, match its backend selector. A nil labels map stands for a server
+// that does not exist and so is never selected.
+func hasBackend(loadbalancer *kubeberth.ResponseLoadBalancer, server string, labels map[string]string) bool {
+	for _, destination := range loadbalancer.Backends {
+		if destination.Server == server {
+			return true
+		}
+	}
+
+	if len(loadbalancer.BackendSelector) == 0 || labels == nil {
+		return false
+	}
+
+	filter := &listFilter{labels: loadbalancer.BackendSelector}
+	return filter.match(server, labels)
+}
+
+func (d loadbalancersDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data loadbalancersDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseLoadBalancers, err := d.provider.client.GetAllLoadBalancers(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("loadbalancers: %+v\n", responseLoadBalancers))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list loadbalancers, got error: %s", err))
+		return
+	}
+
+	// Load balancers selecting their backends by label forward to the backend
+	// server if its labels match, so look them up.
+	var backendLabels map[string]string
+	if !data.Backend.Null {
+		responseServer, err := d.provider.client.GetServer(ctx, data.Backend.Value)
+		tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server %q, got error: %s", data.Backend.Value, err))
+			return
+		}
+		if err == nil {
+			backendLabels = responseServer.Labels
+			if backendLabels == nil {
+				backendLabels = map[string]string{}
+			}
+		}
+	}
+
+	data.LoadBalancers = []loadbalancerDataSourceData{}
+	for i := range responseLoadBalancers {
+		loadbalancer := &responseLoadBalancers[i]
+		if !data.match(filter, loadbalancer, backendLabels) {
+			continue
+		}
+		data.LoadBalancers = append(data.LoadBalancers, *newLoadBalancerDataSourceData(loadbalancer))
+	}
+
+	sort.Slice(data.LoadBalancers, func(i, j int) bool {
+		return data.LoadBalancers[i].Name.Value < data.LoadBalancers[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
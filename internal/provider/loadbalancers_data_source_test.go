@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLoadBalancersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccLoadBalancersDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_loadbalancers.test", "loadbalancers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_loadbalancers.test", "loadbalancers.0.name", "kubeberth_loadbalancer.test", "name"),
+					resource.TestCheckResourceAttr("data.kubeberth_loadbalancers.selector", "loadbalancers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_loadbalancers.selector", "loadbalancers.0.name", "kubeberth_loadbalancer.selector", "name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccLoadBalancersDataSourceConfig = `
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-loadbalancers"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-loadbalancers"
+
+  labels = {
+    "terraform-acc" = "loadbalancers"
+  }
+}
+
+resource "kubeberth_loadbalancer" "test" {
+  name     = "terraform-acc-loadbalancers"
+  backends = [
+    {
+      server = kubeberth_server.test.name
+    },
+  ]
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+    },
+  ]
+}
+
+resource "kubeberth_loadbalancer" "selector" {
+  name = "terraform-acc-loadbalancers-selector"
+  backend_selector = {
+    "terraform-acc" = "loadbalancers"
+  }
+  ports = [
+    {
+      name        = "http"
+      protocol    = "TCP"
+      port        = 80
+      target_port = 80
+    },
+  ]
+}
+
+data "kubeberth_loadbalancers" "test" {
+  name_regex = "^${kubeberth_loadbalancer.test.name}$"
+  backend    = kubeberth_server.test.name
+}
+
+data "kubeberth_loadbalancers" "selector" {
+  name_regex = "^${kubeberth_loadbalancer.selector.name}$"
+  backend    = kubeberth_server.test.name
+}
+`
+
+func TestLoadBalancersDataSourceMatch(t *testing.T) {
+	loadbalancer := &kubeberth.ResponseLoadBalancer{
+		Name:     "web",
+		State:    "Active",
+		Backends: []kubeberth.Destination{{Server: "web-1"}, {Server: "web-2"}},
+	}
+
+	tests := map[string]struct {
+		data loadbalancersDataSourceData
+		want bool
+	}{
+		"no filters": {
+			data: loadbalancersDataSourceData{State: types.String{Null: true}, Backend: types.String{Null: true}},
+			want: true,
+		},
+		"state matches": {
+			data: loadbalancersDataSourceData{State: types.String{Value: "Active"}, Backend: types.String{Null: true}},
+			want: true,
+		},
+		"state differs": {
+			data: loadbalancersDataSourceData{State: types.String{Value: "Pending"}, Backend: types.String{Null: true}},
+			want: false,
+		},
+		"backend listed": {
+			data: loadbalancersDataSourceData{State: types.String{Null: true}, Backend: types.String{Value: "web-2"}},
+			want: true,
+		},
+		"backend not listed": {
+			data: loadbalancersDataSourceData{State: types.String{Null: true}, Backend: types.String{Value: "db-1"}},
+			want: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.data.match(testListFilter(t), loadbalancer, map[string]string{}); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHasBackend(t *testing.T) {
+	static := &kubeberth.ResponseLoadBalancer{
+		Name:     "web",
+		Backends: []kubeberth.Destination{{Server: "web-1"}},
+	}
+	selector := &kubeberth.ResponseLoadBalancer{
+		Name:            "web",
+		BackendSelector: map[string]string{"role": "web"},
+	}
+
+	tests := map[string]struct {
+		loadbalancer *kubeberth.ResponseLoadBalancer
+		server       string
+		labels       map[string]string
+		want         bool
+	}{
+		"listed": {
+			loadbalancer: static,
+			server:       "web-1",
+			labels:       map[string]string{},
+			want:         true,
+		},
+		"not listed": {
+			loadbalancer: static,
+			server:       "web-2",
+			labels:       map[string]string{"role": "web"},
+			want:         false,
+		},
+		"selected": {
+			loadbalancer: selector,
+			server:       "web-2",
+			labels:       map[string]string{"role": "web", "env": "prod"},
+			want:         true,
+		},
+		"label differs": {
+			loadbalancer: selector,
+			server:       "db-1",
+			labels:       map[string]string{"role": "db"},
+			want:         false,
+		},
+		"unlabeled": {
+			loadbalancer: selector,
+			server:       "web-2",
+			labels:       map[string]string{},
+			want:         false,
+		},
+		"server does not exist": {
+			loadbalancer: selector,
+			server:       "web-3",
+			labels:       nil,
+			want:         false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := hasBackend(test.loadbalancer, test.server, test.labels); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
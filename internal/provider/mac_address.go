@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseMACAddress parses a 48-bit MAC address in any of the notations
+// accepted by net.ParseMAC.
+func parseMACAddress(value string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid MAC address", value)
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("%q is not a 48-bit MAC address", value)
+	}
+
+	return mac, nil
+}
+
+// normalizeMACAddress returns value in lower-case colon notation, or value
+// unchanged if it is not a valid MAC address.
+func normalizeMACAddress(value string) string {
+	mac, err := parseMACAddress(value)
+	if err != nil {
+		return value
+	}
+
+	return mac.String()
+}
+
+// macAddressesEqual reports whether a and b denote the same MAC address,
+// ignoring notation and case.
+func macAddressesEqual(a, b string) bool {
+	return normalizeMACAddress(a) == normalizeMACAddress(b)
+}
+
+// macAddressValue converts a MAC address reported by kubeberth to the
+// lower-case colon notation kept in state. An empty address is null.
+func macAddressValue(value string) types.String {
+	if value == "" {
+		return types.String{Null: true}
+	}
+
+	return types.String{Value: normalizeMACAddress(value)}
+}
+
+// macAddressSemanticEquality keeps the MAC address in state when the
+// configuration denotes the same address in another notation, e.g. upper case
+// or dashes, so that only actual changes of the address are planned.
+type macAddressSemanticEquality struct{}
+
+func (m macAddressSemanticEquality) Description(ctx context.Context) string {
+	return "Keeps the MAC address in state if the configured one is the same address in another notation."
+}
+
+func (m macAddressSemanticEquality) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m macAddressSemanticEquality) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if req.AttributeConfig == nil || req.AttributeState == nil {
+		return
+	}
+
+	var config, state types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &config)
+	diags.Append(tfsdk.ValueAs(ctx, req.AttributeState, &state)...)
+	resp.Diagnostics.Append(diags...)
+
+	if diags.HasError() || config.Null || config.Unknown || state.Null || state.Unknown {
+		return
+	}
+
+	if config.Value != state.Value && macAddressesEqual(config.Value, state.Value) {
+		resp.AttributePlan = state
+	}
+}
+
+// macAddressPlanModifier suppresses differences in the notation of a MAC
+// address between the configuration and the state.
+func macAddressPlanModifier() tfsdk.AttributePlanModifier {
+	return macAddressSemanticEquality{}
+}
+
+// macAddressMulticast reports whether the group bit of mac is set.
+func macAddressMulticast(mac net.HardwareAddr) bool {
+	return mac[0]&0x01 != 0
+}
+
+// macAddressLocal reports whether mac is locally administered.
+func macAddressLocal(mac net.HardwareAddr) bool {
+	return mac[0]&0x02 != 0
+}
+
+// deriveMACAddress derives a stable unicast, locally administered MAC
+// address from seed and the server name.
+func deriveMACAddress(seed, name string) string {
+	sum := sha256.Sum256([]byte(seed + "\x00" + name))
+
+	mac := net.HardwareAddr(sum[:6])
+	mac[0] = mac[0]&^0x01 | 0x02
+
+	return mac.String()
+}
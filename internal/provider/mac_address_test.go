@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNormalizeMACAddress(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"canonical": {
+			value: "52:42:00:11:22:33",
+			want:  "52:42:00:11:22:33",
+		},
+		"upper case": {
+			value: "52:42:00:AA:BB:CC",
+			want:  "52:42:00:aa:bb:cc",
+		},
+		"dashes": {
+			value: "52-42-00-aa-bb-cc",
+			want:  "52:42:00:aa:bb:cc",
+		},
+		"dots": {
+			value: "5242.00aa.bbcc",
+			want:  "52:42:00:aa:bb:cc",
+		},
+		"invalid": {
+			value: "not-a-mac",
+			want:  "not-a-mac",
+		},
+		"64-bit": {
+			value: "52:42:00:11:22:33:44:55",
+			want:  "52:42:00:11:22:33:44:55",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeMACAddress(test.value); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMACAddressValue(t *testing.T) {
+	if got := macAddressValue(""); !got.Null {
+		t.Errorf("expected null, got %v", got)
+	}
+	if got := macAddressValue("52-42-00-AA-BB-CC"); got.Null || got.Value != "52:42:00:aa:bb:cc" {
+		t.Errorf("expected 52:42:00:aa:bb:cc, got %v", got)
+	}
+}
+
+func TestDeriveMACAddress(t *testing.T) {
+	a := deriveMACAddress("prod", "web-1")
+
+	if a != deriveMACAddress("prod", "web-1") {
+		t.Errorf("expected the same address for the same seed and name")
+	}
+	if a == deriveMACAddress("prod", "web-2") {
+		t.Errorf("expected different addresses for different names")
+	}
+	if a == deriveMACAddress("staging", "web-1") {
+		t.Errorf("expected different addresses for different seeds")
+	}
+	// The separator keeps seed and name from running into each other.
+	if deriveMACAddress("ab", "c") == deriveMACAddress("a", "bc") {
+		t.Errorf("expected different addresses for ab/c and a/bc")
+	}
+
+	for _, name := range []string{"web-1", "web-2", "db-1", "db-2", "cache"} {
+		value := deriveMACAddress("prod", name)
+
+		mac, err := parseMACAddress(value)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if macAddressMulticast(mac) {
+			t.Errorf("%s: expected a unicast address, got %s", name, value)
+		}
+		if !macAddressLocal(mac) {
+			t.Errorf("%s: expected a locally administered address, got %s", name, value)
+		}
+		if value != normalizeMACAddress(value) {
+			t.Errorf("%s: expected canonical notation, got %s", name, value)
+		}
+	}
+}
+
+func TestMACAddressValidator(t *testing.T) {
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"null": {
+			value: types.String{Null: true},
+		},
+		"unknown": {
+			value: types.String{Unknown: true},
+		},
+		"locally administered": {
+			value: types.String{Value: "52:42:00:11:22:33"},
+		},
+		"locally administered upper case": {
+			value: types.String{Value: "02-00-00-AA-BB-CC"},
+		},
+		"globally administered": {
+			value:     types.String{Value: "00:1a:2b:3c:4d:5e"},
+			wantError: true,
+		},
+		"multicast": {
+			value:     types.String{Value: "53:42:00:11:22:33"},
+			wantError: true,
+		},
+		"invalid": {
+			value:     types.String{Value: "52:42:00:11:22"},
+			wantError: true,
+		},
+		"64-bit": {
+			value:     types.String{Value: "52:42:00:11:22:33:44:55"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := tfsdk.ValidateAttributeRequest{
+				AttributePath:   tftypes.NewAttributePath().WithAttributeName("mac_address"),
+				AttributeConfig: test.value,
+			}
+			resp := &tfsdk.ValidateAttributeResponse{}
+
+			macAddress().Validate(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != test.wantError {
+				t.Errorf("expected error %t, got diagnostics %v", test.wantError, resp.Diagnostics)
+			}
+			if len(resp.Diagnostics) != 0 && !test.wantError {
+				t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMACAddressPlanModifier(t *testing.T) {
+	tests := map[string]struct {
+		config attr.Value
+		state  attr.Value
+		want   types.String
+	}{
+		"same notation": {
+			config: types.String{Value: "52:42:00:aa:bb:cc"},
+			state:  types.String{Value: "52:42:00:aa:bb:cc"},
+			want:   types.String{Value: "52:42:00:aa:bb:cc"},
+		},
+		"other notation": {
+			config: types.String{Value: "52-42-00-AA-BB-CC"},
+			state:  types.String{Value: "52:42:00:aa:bb:cc"},
+			want:   types.String{Value: "52:42:00:aa:bb:cc"},
+		},
+		"changed address": {
+			config: types.String{Value: "52-42-00-AA-BB-CD"},
+			state:  types.String{Value: "52:42:00:aa:bb:cc"},
+			want:   types.String{Value: "52-42-00-AA-BB-CD"},
+		},
+		"create": {
+			config: types.String{Value: "52-42-00-AA-BB-CC"},
+			state:  nil,
+			want:   types.String{Value: "52-42-00-AA-BB-CC"},
+		},
+		"null state": {
+			config: types.String{Value: "52-42-00-AA-BB-CC"},
+			state:  types.String{Null: true},
+			want:   types.String{Value: "52-42-00-AA-BB-CC"},
+		},
+		"null config": {
+			config: types.String{Null: true},
+			state:  types.String{Value: "52:42:00:aa:bb:cc"},
+			want:   types.String{Null: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var config types.String
+			diags := tfsdk.ValueAs(context.Background(), test.config, &config)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			req := tfsdk.ModifyAttributePlanRequest{
+				AttributePath:   tftypes.NewAttributePath().WithAttributeName("mac_address"),
+				AttributeConfig: test.config,
+				AttributeState:  test.state,
+				AttributePlan:   config,
+			}
+			resp := &tfsdk.ModifyAttributePlanResponse{
+				AttributePlan: req.AttributePlan,
+			}
+
+			macAddressPlanModifier().Modify(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !resp.AttributePlan.Equal(test.want) {
+				t.Errorf("expected %v, got %v", test.want, resp.AttributePlan)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// addNamespaceAttribute adds the namespace attribute shared by every
+// resource. It defaults to the provider namespace and moving an object to
+// another namespace replaces it.
+func addNamespaceAttribute(attributes map[string]tfsdk.Attribute) map[string]tfsdk.Attribute {
+	attributes["namespace"] = tfsdk.Attribute{
+		MarkdownDescription: "Namespace of the object. Defaults to the provider `namespace`. Changing it forces a new resource.",
+		Type:                types.StringType,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			tfsdk.UseStateForUnknown(),
+			tfsdk.RequiresReplace(),
+		},
+	}
+
+	return attributes
+}
+
+// namespacedClient returns the client for namespace, or the client for the
+// provider namespace if namespace is not set.
+func (p provider) namespacedClient(namespace types.String) *kubeberth.Client {
+	if namespace.Null || namespace.Unknown || namespace.Value == "" || namespace.Value == p.namespace {
+		return p.client
+	}
+
+	return p.client.InNamespace(namespace.Value)
+}
+
+// resolveNamespace returns namespace, or the provider namespace if namespace
+// is not set. The result is null if neither is set, leaving the choice to
+// the API.
+func (p provider) resolveNamespace(namespace types.String) types.String {
+	if !namespace.Null && !namespace.Unknown {
+		return namespace
+	}
+
+	return types.String{Null: p.namespace == "", Value: p.namespace}
+}
+
+// modifyPlanNamespace plans the provider namespace for resources that do not
+// set their own, replacing them when the provider namespace changes.
+func modifyPlanNamespace(ctx context.Context, p provider, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() || p.namespace == "" {
+		return
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName("namespace")
+
+	var namespace types.String
+	diags := req.Config.GetAttribute(ctx, path, &namespace)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || !namespace.Null {
+		return
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path, p.namespace)
+	resp.Diagnostics.Append(diags...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state types.String
+	diags = req.State.GetAttribute(ctx, path, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if !resp.Diagnostics.HasError() && !state.Null && state.Value != p.namespace {
+		resp.RequiresReplace = append(resp.RequiresReplace, path)
+	}
+}
+
+// importNamespacedName imports an object from an ID in `namespace/name` or
+// `name` form into the namespace attribute and the attribute at namePath.
+func importNamespacedName(ctx context.Context, namePath *tftypes.AttributePath, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) > 2 || parts[len(parts)-1] == "" || (len(parts) == 2 && parts[0] == "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: namespace/name or name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	if len(parts) == 2 {
+		diags := resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("namespace"), parts[0])
+		resp.Diagnostics.Append(diags...)
+	}
+
+	diags := resp.State.SetAttribute(ctx, namePath, parts[len(parts)-1])
+	resp.Diagnostics.Append(diags...)
+}
+
+// refreshNamespace returns the namespace reported by kubeberth, keeping
+// namespace if the API does not report one.
+func refreshNamespace(namespace types.String, current string) types.String {
+	if current == "" {
+		return namespace
+	}
+
+	return types.String{Value: current}
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportNamespacedName(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		wantNamespace types.String
+		wantName      types.String
+		wantError     bool
+	}{
+		"name": {
+			id:            "web-1",
+			wantNamespace: types.String{Null: true},
+			wantName:      types.String{Value: "web-1"},
+		},
+		"namespace and name": {
+			id:            "prod/web-1",
+			wantNamespace: types.String{Value: "prod"},
+			wantName:      types.String{Value: "web-1"},
+		},
+		"too many parts": {
+			id:        "prod/web/1",
+			wantError: true,
+		},
+		"empty namespace": {
+			id:        "/web-1",
+			wantError: true,
+		},
+		"empty name": {
+			id:        "prod/",
+			wantError: true,
+		},
+		"empty": {
+			id:        "",
+			wantError: true,
+		},
+	}
+
+	ctx := context.Background()
+	schema, diags := diskResourceType{}.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := tfsdk.ImportResourceStateRequest{ID: test.id}
+			resp := &tfsdk.ImportResourceStateResponse{
+				State: tfsdk.State{
+					Schema: schema,
+					Raw:    tftypes.NewValue(schema.TerraformType(ctx), nil),
+				},
+			}
+
+			importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != test.wantError {
+				t.Fatalf("expected error %t, got diagnostics %v", test.wantError, resp.Diagnostics)
+			}
+			if test.wantError {
+				return
+			}
+
+			var namespace, name types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("namespace"), &namespace)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("name"), &name)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !namespace.Equal(test.wantNamespace) {
+				t.Errorf("expected namespace %v, got %v", test.wantNamespace, namespace)
+			}
+			if !name.Equal(test.wantName) {
+				t.Errorf("expected name %v, got %v", test.wantName, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = nodesDataSourceType{}
+var _ tfsdk.DataSource = nodesDataSource{}
+
+type nodesDataSourceType struct{}
+
+func (t nodesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["architecture"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return nodes of this architecture, e.g. `arm64`.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["schedulable_only"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return nodes that accept new servers.",
+		Type:                types.BoolType,
+		Optional:            true,
+	}
+	attributes["nodes"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching nodes, sorted by name. The `name` of a node can be used as `hosting` of a server.",
+		Computed:            true,
+		Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+			"name": {
+				MarkdownDescription: "name",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"architecture": {
+				MarkdownDescription: "architecture",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"allocatable_cpu": {
+				MarkdownDescription: "CPU available to servers on the node.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"allocatable_memory": {
+				MarkdownDescription: "Memory available to servers on the node.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"schedulable": {
+				MarkdownDescription: "schedulable",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+			"labels": {
+				MarkdownDescription: "labels",
+				Type:                types.MapType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"servers": {
+				MarkdownDescription: "Number of kubeberth servers running on the node, wherever their `hosting` asks them to be.",
+				Type:                types.Int64Type,
+				Computed:            true,
+			},
+		}, tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the cluster nodes servers can be placed on.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (t nodesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return nodesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type nodeDataSourceData struct {
+	Name              types.String      `tfsdk:"name"`
+	Architecture      types.String      `tfsdk:"architecture"`
+	AllocatableCPU    types.String      `tfsdk:"allocatable_cpu"`
+	AllocatableMemory types.String      `tfsdk:"allocatable_memory"`
+	Schedulable       types.Bool        `tfsdk:"schedulable"`
+	Labels            map[string]string `tfsdk:"labels"`
+	Servers           types.Int64       `tfsdk:"servers"`
+}
+
+type nodesDataSourceData struct {
+	NameRegex       types.String         `tfsdk:"name_regex"`
+	Labels          map[string]string    `tfsdk:"labels"`
+	Architecture    types.String         `tfsdk:"architecture"`
+	SchedulableOnly types.Bool           `tfsdk:"schedulable_only"`
+	Nodes           []nodeDataSourceData `tfsdk:"nodes"`
+}
+
+// match reports whether node passes the filters of the data source.
+func (data *nodesDataSourceData) match(filter *listFilter, node *kubeberth.ResponseNode) bool {
+	if !filter.match(node.Name, node.Labels) {
+		return false
+	}
+	if !data.Architecture.Null && node.Architecture != data.Architecture.Value {
+		return false
+	}
+	if data.SchedulableOnly.Value && !node.Schedulable {
+		return false
+	}
+
+	return true
+}
+
+// countServersByNode returns the number of servers running on each node.
+// Servers are counted where kubeberth reports them running rather than by
+// hosting, which is only a placement request and is empty for servers left
+// to the scheduler.
+func countServersByNode(servers []kubeberth.ResponseServer) map[string]int64 {
+	counts := map[string]int64{}
+	for _, server := range servers {
+		if server.NodeName != "" {
+			counts[server.NodeName]++
+		}
+	}
+
+	return counts
+}
+
+type nodesDataSource struct {
+	provider provider
+}
+
+func (d nodesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data nodesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseNodes, err := d.provider.client.GetAllNodes(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("nodes: %+v\n", responseNodes))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list nodes, got error: %s", err))
+		return
+	}
+
+	responseServers, err := d.provider.client.GetAllServers(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("servers: %+v\n", responseServers))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list servers, got error: %s", err))
+		return
+	}
+
+	hosted := countServersByNode(responseServers)
+
+	data.Nodes = []nodeDataSourceData{}
+	for i := range responseNodes {
+		node := &responseNodes[i]
+		if !data.match(filter, node) {
+			continue
+		}
+
+		labels := node.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		data.Nodes = append(data.Nodes, nodeDataSourceData{
+			Name:              types.String{Value: node.Name},
+			Architecture:      types.String{Value: node.Architecture},
+			AllocatableCPU:    types.String{Value: node.AllocatableCPU},
+			AllocatableMemory: types.String{Value: node.AllocatableMemory},
+			Schedulable:       types.Bool{Value: node.Schedulable},
+			Labels:            labels,
+			Servers:           types.Int64{Value: hosted[node.Name]},
+		})
+	}
+
+	sort.Slice(data.Nodes, func(i, j int) bool {
+		return data.Nodes[i].Name.Value < data.Nodes[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNodesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccNodesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kubeberth_nodes.test", "nodes.0.name"),
+					resource.TestCheckResourceAttr("data.kubeberth_nodes.test", "nodes.0.schedulable", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccNodesDataSourceConfig = `
+data "kubeberth_nodes" "test" {
+  schedulable_only = true
+}
+`
+
+func TestNodesDataSourceMatch(t *testing.T) {
+	tests := map[string]struct {
+		data nodesDataSourceData
+		node kubeberth.ResponseNode
+		want bool
+	}{
+		"no filters": {
+			data: nodesDataSourceData{Architecture: types.String{Null: true}, SchedulableOnly: types.Bool{Null: true}},
+			node: kubeberth.ResponseNode{Name: "node-1", Architecture: "arm64"},
+			want: true,
+		},
+		"architecture matches": {
+			data: nodesDataSourceData{Architecture: types.String{Value: "arm64"}, SchedulableOnly: types.Bool{Null: true}},
+			node: kubeberth.ResponseNode{Name: "node-1", Architecture: "arm64"},
+			want: true,
+		},
+		"architecture differs": {
+			data: nodesDataSourceData{Architecture: types.String{Value: "amd64"}, SchedulableOnly: types.Bool{Null: true}},
+			node: kubeberth.ResponseNode{Name: "node-1", Architecture: "arm64"},
+			want: false,
+		},
+		"schedulable only": {
+			data: nodesDataSourceData{Architecture: types.String{Null: true}, SchedulableOnly: types.Bool{Value: true}},
+			node: kubeberth.ResponseNode{Name: "node-1", Schedulable: true},
+			want: true,
+		},
+		"cordoned": {
+			data: nodesDataSourceData{Architecture: types.String{Null: true}, SchedulableOnly: types.Bool{Value: true}},
+			node: kubeberth.ResponseNode{Name: "node-1", Schedulable: false},
+			want: false,
+		},
+		"cordoned without schedulable_only": {
+			data: nodesDataSourceData{Architecture: types.String{Null: true}, SchedulableOnly: types.Bool{Value: false}},
+			node: kubeberth.ResponseNode{Name: "node-1", Schedulable: false},
+			want: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.data.match(testListFilter(t), &test.node); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCountServersByNode(t *testing.T) {
+	servers := []kubeberth.ResponseServer{
+		{Name: "web-1", Hosting: "node-1", NodeName: "node-1"},
+		{Name: "web-2", Hosting: "node-1", NodeName: "node-2"},
+		{Name: "db-1", NodeName: "node-2"},
+		{Name: "stopped", Hosting: "node-1"},
+	}
+
+	got := countServersByNode(servers)
+	want := map[string]int64{"node-1": 1, "node-2": 2}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for node, count := range want {
+		if got[node] != count {
+			t.Errorf("expected %d servers on %s, got %d", count, node, got[node])
+		}
+	}
+}
@@ -30,6 +30,13 @@ type provider struct {
 	// that the provider was previously configured.
 	configured bool
 
+	// defaultLabels are merged into the labels of every resource.
+	defaultLabels map[string]string
+
+	// namespace is the namespace of resources that do not set their own.
+	// Empty leaves the choice to the API.
+	namespace string
+
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
@@ -38,7 +45,9 @@ type provider struct {
 
 // providerData can be used to store data from the Terraform configuration.
 type providerData struct {
-	URL types.String `tfsdk:"url"`
+	URL           types.String `tfsdk:"url"`
+	Namespace     types.String `tfsdk:"namespace"`
+	DefaultLabels types.Map    `tfsdk:"default_labels"`
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -75,28 +84,76 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		return
 	}
 
+	var namespace string
+	if data.Namespace.Unknown {
+		resp.Diagnostics.AddError(
+			"Unable to create client",
+			"Cannot use unknown value as namespace",
+		)
+		return
+	}
+
+	if data.Namespace.Null {
+		namespace = os.Getenv("KUBEBERTH_NAMESPACE")
+	} else {
+		namespace = data.Namespace.Value
+	}
+
+	if data.DefaultLabels.Unknown || hasUnknownElement(data.DefaultLabels) {
+		resp.Diagnostics.AddError(
+			"Unable to configure default labels",
+			"Cannot use unknown value as default_labels",
+		)
+		return
+	}
+
+	defaultLabels := map[string]string{}
+	if !data.DefaultLabels.Null {
+		diags = data.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// If the upstream provider SDK or HTTP client requires configuration, such
 	// as authentication or logging, this is a great opportunity to do so.
 	config := kubeberth.NewConfig(url)
+	config.Namespace = namespace
 	client := kubeberth.NewClient(config)
 	p.client = client
+	p.namespace = namespace
+	p.defaultLabels = defaultLabels
 	p.configured = true
 }
 
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"kubeberth_loadbalancer": loadbalancerResourceType{},
-		"kubeberth_server":       serverResourceType{},
-		"kubeberth_disk":         diskResourceType{},
-		"kubeberth_cloudinit":    cloudinitResourceType{},
-		"kubeberth_archive":      archiveResourceType{},
-		"kubeberth_isoimage":     isoimageResourceType{},
+		"kubeberth_loadbalancer":    loadbalancerResourceType{},
+		"kubeberth_server":          serverResourceType{},
+		"kubeberth_server_power":    serverPowerResourceType{},
+		"kubeberth_disk":            diskResourceType{},
+		"kubeberth_disk_attachment": diskAttachmentResourceType{},
+		"kubeberth_cloudinit":       cloudinitResourceType{},
+		"kubeberth_archive":         archiveResourceType{},
+		"kubeberth_isoimage":        isoimageResourceType{},
 	}, nil
 }
 
 func (p *provider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
 	return map[string]tfsdk.DataSourceType{
-		"scaffolding_example": exampleDataSourceType{},
+		"kubeberth_cluster_info":  clusterInfoDataSourceType{},
+		"kubeberth_server":        serverDataSourceType{},
+		"kubeberth_servers":       serversDataSourceType{},
+		"kubeberth_disk":          diskDataSourceType{},
+		"kubeberth_disks":         disksDataSourceType{},
+		"kubeberth_archive":       archiveDataSourceType{},
+		"kubeberth_archives":      archivesDataSourceType{},
+		"kubeberth_isoimages":     isoimagesDataSourceType{},
+		"kubeberth_cloudinits":    cloudinitsDataSourceType{},
+		"kubeberth_loadbalancers": loadbalancersDataSourceType{},
+		"kubeberth_nodes":         nodesDataSourceType{},
 	}, nil
 }
 
@@ -108,6 +165,16 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Type:                types.StringType,
 				Required:            true,
 			},
+			"namespace": {
+				MarkdownDescription: "Namespace of resources that do not set their own. Defaults to the `KUBEBERTH_NAMESPACE` environment variable, then to the API default.",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"default_labels": {
+				MarkdownDescription: "Labels added to every kubeberth object managed by the provider, e.g. the owning team or the Terraform workspace. Labels set on a resource take precedence.",
+				Type:                types.MapType{ElemType: types.StringType},
+				Optional:            true,
+			},
 		},
 	}, nil
 }
@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,7 +20,7 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"kubeberth": providerserver.NewProtocol6WithError(New("test")()),
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -20,3 +28,72 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccImportStateIdFunc builds the import identifier of resourceName from
+// its namespace followed by the given attributes, separated by slashes. The
+// resources have no id attribute, so the identifier cannot be derived by the
+// test framework itself.
+func testAccImportStateIdFunc(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		var parts []string
+		if namespace := rs.Primary.Attributes["namespace"]; namespace != "" {
+			parts = append(parts, namespace)
+		}
+		for _, attribute := range attributes {
+			parts = append(parts, rs.Primary.Attributes[attribute])
+		}
+
+		return strings.Join(parts, "/"), nil
+	}
+}
+
+// testConfig returns a configuration for resourceType that sets the given
+// attributes and leaves every other attribute null.
+func testConfig(t *testing.T, resourceType tfsdk.ResourceType, attributes map[string]interface{}) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	schema, diags := resourceType.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	objectType := schema.TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	state := tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+	for name, value := range attributes {
+		diags = state.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName(name), value)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics setting %s: %v", name, diags)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schema,
+		Raw:    state.Raw,
+	}
+}
+
+// testErrorCount returns the number of errors in diags.
+func testErrorCount(diags diag.Diagnostics) int {
+	count := 0
+	for _, d := range diags {
+		if d.Severity() == diag.SeverityError {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeberth/kubeberth-go"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = serverDataSourceType{}
+var _ tfsdk.DataSource = serverDataSource{}
+
+type serverDataSourceType struct{}
+
+func (t serverDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := serverAttributes()
+	attributes["name"] = tfsdk.Attribute{
+		MarkdownDescription: "name",
+		Type:                types.StringType,
+		Required:            true,
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing kubeberth server by name.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+// serverAttributes returns the computed attributes describing a server. They
+// are shared by the kubeberth_server and kubeberth_servers data sources.
+func serverAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"name": {
+			MarkdownDescription: "name",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"running": {
+			MarkdownDescription: "running",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"cpu": {
+			MarkdownDescription: "cpu",
+			Type:                types.Int64Type,
+			Computed:            true,
+		},
+		"cpu_topology": {
+			MarkdownDescription: "cpu_topology",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"sockets": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+				"cores": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+				"threads": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+			}),
+		},
+		"cpu_model": {
+			MarkdownDescription: "cpu_model",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"dedicated_cpu": {
+			MarkdownDescription: "dedicated_cpu",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"firmware": {
+			MarkdownDescription: "firmware",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"secure_boot": {
+			MarkdownDescription: "secure_boot",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"tpm": {
+			MarkdownDescription: "tpm",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"memory": {
+			MarkdownDescription: "memory",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"memory_request": {
+			MarkdownDescription: "memory_request",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"hugepages": {
+			MarkdownDescription: "hugepages",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"mac_address": {
+			MarkdownDescription: "mac_address",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"hostname": {
+			MarkdownDescription: "hostname",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"hosting": {
+			MarkdownDescription: "hosting",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"node_selector": {
+			MarkdownDescription: "node_selector",
+			Type:                types.MapType{ElemType: types.StringType},
+			Computed:            true,
+		},
+		"tolerations": {
+			MarkdownDescription: "tolerations",
+			Computed:            true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"key": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"operator": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"value": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"effect": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+		},
+		"affinity": {
+			MarkdownDescription: "affinity",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"node_labels": {
+					Type:     types.MapType{ElemType: types.StringType},
+					Computed: true,
+				},
+				"servers": {
+					Type:     types.ListType{ElemType: types.StringType},
+					Computed: true,
+				},
+				"required": {
+					Type:     types.BoolType,
+					Computed: true,
+				},
+			}),
+		},
+		"anti_affinity": {
+			MarkdownDescription: "anti_affinity",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"node_labels": {
+					Type:     types.MapType{ElemType: types.StringType},
+					Computed: true,
+				},
+				"servers": {
+					Type:     types.ListType{ElemType: types.StringType},
+					Computed: true,
+				},
+				"required": {
+					Type:     types.BoolType,
+					Computed: true,
+				},
+			}),
+		},
+		"disks": {
+			MarkdownDescription: "disks",
+			Computed:            true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"boot_order": {
+					Type:     types.Int64Type,
+					Computed: true,
+				},
+				"bus": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"read_only": {
+					Type:     types.BoolType,
+					Computed: true,
+				},
+				"serial": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+		},
+		"network_interfaces": {
+			MarkdownDescription: "network_interfaces",
+			Computed:            true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"network": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"mac_address": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"model": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+				"primary": {
+					Type:     types.BoolType,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+		},
+		"isoimage": {
+			MarkdownDescription: "isoimage",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}),
+		},
+		"cloudinit": {
+			MarkdownDescription: "cloudinit",
+			Computed:            true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}),
+		},
+		"status": {
+			MarkdownDescription: "status",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"ip_addresses": {
+			MarkdownDescription: "ip_addresses",
+			Type:                types.ListType{ElemType: types.StringType},
+			Computed:            true,
+		},
+	}
+}
+
+func (t serverDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return serverDataSource{
+		provider: provider,
+	}, diags
+}
+
+type serverDataSourceData struct {
+	Name        types.String   `tfsdk:"name"`
+	Running     types.Bool     `tfsdk:"running"`
+	CPU         types.Int64    `tfsdk:"cpu"`
+	Memory      types.String   `tfsdk:"memory"`
+	MACAddress  types.String   `tfsdk:"mac_address"`
+	Hostname    types.String   `tfsdk:"hostname"`
+	Hosting     types.String   `tfsdk:"hosting"`
+	Disks       []diskData     `tfsdk:"disks"`
+	ISOImage    *isoimageData  `tfsdk:"isoimage"`
+	CloudInit   *cloudinitData `tfsdk:"cloudinit"`
+	Status      types.String   `tfsdk:"status"`
+	IPAddresses []types.String `tfsdk:"ip_addresses"`
+
+	NetworkInterfaces []networkInterfaceData `tfsdk:"network_interfaces"`
+	CPUTopology       *cpuTopologyData       `tfsdk:"cpu_topology"`
+	CPUModel          types.String           `tfsdk:"cpu_model"`
+	DedicatedCPU      types.Bool             `tfsdk:"dedicated_cpu"`
+	Firmware          types.String           `tfsdk:"firmware"`
+	SecureBoot        types.Bool             `tfsdk:"secure_boot"`
+	TPM               types.Bool             `tfsdk:"tpm"`
+	MemoryRequest     types.String           `tfsdk:"memory_request"`
+	HugePages         types.String           `tfsdk:"hugepages"`
+	NodeSelector      map[string]string      `tfsdk:"node_selector"`
+	Tolerations       []tolerationData       `tfsdk:"tolerations"`
+	Affinity          *affinityData          `tfsdk:"affinity"`
+	AntiAffinity      *affinityData          `tfsdk:"anti_affinity"`
+}
+
+type serverDataSource struct {
+	provider provider
+}
+
+func newServerDataSourceData(server *kubeberth.ResponseServer) (*serverDataSourceData, error) {
+	cpu, err := resource.ParseQuantity(server.CPU)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpu %q: %w", server.CPU, err)
+	}
+
+	data := &serverDataSourceData{
+		Name:        types.String{Value: server.Name},
+		Running:     types.Bool{Value: server.Running},
+		CPU:         types.Int64{Value: cpu.Value()},
+		Memory:      types.String{Value: server.Memory},
+		MACAddress:  types.String{Value: normalizeMACAddress(server.MACAddress)},
+		Hostname:    types.String{Value: server.Hostname},
+		Hosting:     types.String{Value: server.Hosting},
+		Disks:       []diskData{},
+		Status:      types.String{Value: server.State},
+		IPAddresses: []types.String{},
+
+		NetworkInterfaces: []networkInterfaceData{},
+		CPUModel:          types.String{Null: server.CPUModel == "", Value: server.CPUModel},
+		DedicatedCPU:      types.Bool{Value: server.DedicatedCPU},
+		Firmware:          types.String{Null: server.Firmware == "", Value: server.Firmware},
+		SecureBoot:        types.Bool{Value: server.SecureBoot},
+		TPM:               types.Bool{Value: server.TPM},
+		MemoryRequest:     types.String{Null: server.MemoryRequest == "", Value: server.MemoryRequest},
+		HugePages:         types.String{Null: server.HugePages == "", Value: server.HugePages},
+		NodeSelector:      server.NodeSelector,
+		Tolerations:       []tolerationData{},
+	}
+
+	if data.NodeSelector == nil {
+		data.NodeSelector = map[string]string{}
+	}
+
+	for i := range server.Tolerations {
+		data.Tolerations = append(data.Tolerations, newTolerationData(&server.Tolerations[i]))
+	}
+
+	if server.Affinity != nil {
+		data.Affinity = newAffinityData(server.Affinity)
+	}
+
+	if server.AntiAffinity != nil {
+		data.AntiAffinity = newAffinityData(server.AntiAffinity)
+	}
+
+	if server.CPUTopology != nil {
+		data.CPUTopology = newCPUTopologyData(server.CPUTopology)
+	}
+
+	for i := range server.Disks {
+		data.Disks = append(data.Disks, newDiskData(&server.Disks[i]))
+	}
+
+	for i := range server.NetworkInterfaces {
+		data.NetworkInterfaces = append(data.NetworkInterfaces, newNetworkInterfaceData(&server.NetworkInterfaces[i]))
+	}
+
+	if server.ISOImage != nil {
+		data.ISOImage = &isoimageData{Name: types.String{Value: server.ISOImage.Name}}
+	}
+
+	if server.CloudInit != nil {
+		data.CloudInit = &cloudinitData{Name: types.String{Value: server.CloudInit.Name}}
+	}
+
+	for _, ip := range server.IPAddresses {
+		data.IPAddresses = append(data.IPAddresses, types.String{Value: ip})
+	}
+
+	return data, nil
+}
+
+func (d serverDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var config serverDataSourceData
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	responseServer, err := d.provider.client.GetServer(ctx, config.Name.Value)
+	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server %q, got error: %s", config.Name.Value, err))
+		return
+	}
+
+	data, err := newServerDataSourceData(responseServer)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server %q, got error: %s", config.Name.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccServerDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccServerDataSourceConfig("terraform-acc-server"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_server.test", "name", "terraform-acc-server"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_server.test", "cpu", "kubeberth_server.test", "cpu"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_server.test", "memory", "kubeberth_server.test", "memory"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_server.test", "hostname", "kubeberth_server.test", "hostname"),
+					resource.TestCheckResourceAttrSet("data.kubeberth_server.test", "status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServerDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = %[1]q
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = %[1]q
+}
+
+data "kubeberth_server" "test" {
+  name = kubeberth_server.test.name
+}
+`, name)
+}
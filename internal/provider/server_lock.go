@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// serverLocks serializes read-modify-write updates of a single server, such
+// as several kubeberth_disk_attachment resources changing its disk list in
+// parallel.
+var serverLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{
+	locks: map[string]*sync.Mutex{},
+}
+
+// lockServer locks the named server in namespace and returns the function
+// unlocking it. Servers are told apart by namespace and name, resolving an
+// unset namespace to the provider one, so that same-named servers in other
+// namespaces do not wait for each other.
+func (p provider) lockServer(namespace types.String, name string) func() {
+	key := p.resolveNamespace(namespace).Value + "/" + name
+
+	serverLocks.Lock()
+	lock, ok := serverLocks.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		serverLocks.locks[key] = lock
+	}
+	serverLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// modifyServer reads the named server, applies change to it and writes it
+// back unless change reports that nothing changed. The caller must hold the
+// server lock; the lock is not reentrant, so helpers called with it held use
+// modifyServer rather than locking again.
+func modifyServer(ctx context.Context, client *kubeberth.Client, name string, change func(*kubeberth.RequestServer) bool) error {
+	server, err := client.GetServer(ctx, name)
+	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", server))
+	if err != nil {
+		return err
+	}
+
+	requestServer, err := newRequestServerFromResponse(server)
+	if err != nil {
+		return err
+	}
+	if !change(requestServer) {
+		return nil
+	}
+
+	responseServer, err := client.UpdateServer(ctx, name, requestServer)
+	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
+	return err
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLockServer(t *testing.T) {
+	p := provider{namespace: "prod"}
+
+	// lockedWithin reports whether name in namespace can be locked while
+	// prod/web-1 is held.
+	lockedWithin := func(namespace types.String, name string) bool {
+		done := make(chan struct{})
+		go func() {
+			unlock := p.lockServer(namespace, name)
+			unlock()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+
+	unlock := p.lockServer(types.String{Value: "prod"}, "web-1")
+
+	if !lockedWithin(types.String{Value: "staging"}, "web-1") {
+		t.Errorf("expected staging/web-1 not to wait for prod/web-1")
+	}
+	if !lockedWithin(types.String{Value: "prod"}, "web-2") {
+		t.Errorf("expected prod/web-2 not to wait for prod/web-1")
+	}
+	if lockedWithin(types.String{Null: true}, "web-1") {
+		t.Errorf("expected web-1 in the provider namespace to wait for prod/web-1")
+	}
+
+	unlock()
+}
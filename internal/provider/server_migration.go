@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	migrationStrategyLive    = "live"
+	migrationStrategyRestart = "restart"
+
+	defaultMigrationTimeout = 30 * time.Minute
+
+	// Values of ResponseMigration.Phase reported by kubeberth.
+	migrationPhaseSucceeded = "Succeeded"
+	migrationPhaseFailed    = "Failed"
+)
+
+// migrationPlanned reports whether Update has to move the server before
+// anything else changes: hosting is set to another node and the server is
+// not planned to stop. A server planned to stop has hosting written by the
+// update itself, without being migrated or restarted first.
+func migrationPlanned(hosting, priorHosting types.String, running types.Bool) bool {
+	if hosting.Null || hosting.Value == priorHosting.Value {
+		return false
+	}
+
+	return running.Null || running.Value
+}
+
+// moveServer moves a running server to the node named by hosting, either by
+// live migration or by stopping it, changing hosting and starting it again.
+// It returns once the server runs on the new node. The caller must hold the
+// server lock.
+func moveServer(ctx context.Context, client *kubeberth.Client, name string, hosting string, strategy string, timeout time.Duration) error {
+	if strategy == migrationStrategyRestart {
+		tflog.Info(ctx, fmt.Sprintf("moving server %q to %q by restarting it", name, hosting))
+
+		if err := setServerRunning(ctx, client, name, false, timeout); err != nil {
+			return err
+		}
+		err := modifyServer(ctx, client, name, func(server *kubeberth.RequestServer) bool {
+			server.Hosting = hosting
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return setServerRunning(ctx, client, name, true, timeout)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("live migrating server %q to %q", name, hosting))
+
+	migration, err := client.MigrateServer(ctx, name, &kubeberth.RequestMigration{Hosting: hosting})
+	tflog.Trace(ctx, fmt.Sprintf("migration: %+v\n", migration))
+	if err != nil {
+		return err
+	}
+
+	return waitForMigration(ctx, client, name, timeout)
+}
+
+// waitForMigration polls the migration of a server until it succeeds, fails
+// or the timeout expires.
+func waitForMigration(ctx context.Context, client *kubeberth.Client, name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(serverPollInterval)
+	defer ticker.Stop()
+
+	for {
+		migration, err := client.GetServerMigration(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		switch migration.Phase {
+		case migrationPhaseSucceeded:
+			tflog.Info(ctx, fmt.Sprintf("migrated server %q from %q to %q", name, migration.SourceNode, migration.TargetNode))
+			return nil
+		case migrationPhaseFailed:
+			return fmt.Errorf("migration of server %q from %q to %q failed: %s", name, migration.SourceNode, migration.TargetNode, migration.Message)
+		}
+		tflog.Info(ctx, fmt.Sprintf("waiting for migration of server %q from %q to %q, currently %s", name, migration.SourceNode, migration.TargetNode, migration.Phase))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for migration of server %q, currently %s", timeout, name, migration.Phase)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMigrationPlanned(t *testing.T) {
+	node1 := types.String{Value: "node1"}
+	node2 := types.String{Value: "node2"}
+
+	tests := map[string]struct {
+		hosting      types.String
+		priorHosting types.String
+		running      types.Bool
+		want         bool
+	}{
+		"hosting unchanged": {
+			hosting:      node1,
+			priorHosting: node1,
+			running:      types.Bool{Value: true},
+			want:         false,
+		},
+		"hosting removed": {
+			hosting:      types.String{Null: true},
+			priorHosting: node1,
+			running:      types.Bool{Value: true},
+			want:         false,
+		},
+		"hosting changed while running": {
+			hosting:      node2,
+			priorHosting: node1,
+			running:      types.Bool{Value: true},
+			want:         true,
+		},
+		"hosting changed with power managed elsewhere": {
+			hosting:      node2,
+			priorHosting: node1,
+			running:      types.Bool{Null: true},
+			want:         true,
+		},
+		"hosting changed while stopping": {
+			hosting:      node2,
+			priorHosting: node1,
+			running:      types.Bool{Value: false},
+			want:         false,
+		},
+		"hosting set while stopping": {
+			hosting:      node2,
+			priorHosting: types.String{Null: true},
+			running:      types.Bool{Value: false},
+			want:         false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := migrationPlanned(test.hosting, test.priorHosting, test.running); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = serverPowerResourceType{}
+var _ tfsdk.Resource = serverPowerResource{}
+var _ tfsdk.ResourceWithImportState = serverPowerResource{}
+
+const (
+	powerStateRunning = "running"
+	powerStateStopped = "stopped"
+
+	// Values of ResponseServer.State reported by kubeberth.
+	serverStateRunning = "Running"
+	serverStateStopped = "Stopped"
+
+	defaultShutdownTimeout = 5 * time.Minute
+	serverStartTimeout     = 5 * time.Minute
+	serverPollInterval     = 5 * time.Second
+)
+
+type serverPowerResourceType struct{}
+
+func (t serverPowerResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Controls the power state of an existing kubeberth server. Destroying this resource leaves the server as it is.",
+
+		Attributes: addNamespaceAttribute(map[string]tfsdk.Attribute{
+			"server": {
+				MarkdownDescription: "Name of the server.",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"state": {
+				MarkdownDescription: "Desired power state, `running` or `stopped`.",
+				Type:                types.StringType,
+				Required:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(powerStateRunning, powerStateStopped),
+				},
+			},
+			"restart_trigger": {
+				MarkdownDescription: "Arbitrary values that restart a running server whenever they change, e.g. the user data of its cloudinit.",
+				Type:                types.MapType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"shutdown_timeout": {
+				MarkdownDescription: "How long to wait for the guest to shut down gracefully. Defaults to `5m`.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					durationString(),
+				},
+			},
+		}),
+	}, nil
+}
+
+func (t serverPowerResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return serverPowerResource{
+		provider: provider,
+	}, diags
+}
+
+type serverPowerResourceData struct {
+	Server          types.String      `tfsdk:"server"`
+	Namespace       types.String      `tfsdk:"namespace"`
+	State           types.String      `tfsdk:"state"`
+	RestartTrigger  map[string]string `tfsdk:"restart_trigger"`
+	ShutdownTimeout types.String      `tfsdk:"shutdown_timeout"`
+}
+
+func (data *serverPowerResourceData) shutdownTimeout() time.Duration {
+	if data.ShutdownTimeout.Null || data.ShutdownTimeout.Unknown {
+		return defaultShutdownTimeout
+	}
+
+	// Already checked by the attribute validator.
+	timeout, err := time.ParseDuration(data.ShutdownTimeout.Value)
+	if err != nil {
+		return defaultShutdownTimeout
+	}
+
+	return timeout
+}
+
+type serverPowerResource struct {
+	provider provider
+}
+
+// waitForServerState polls the server until it reports the wanted state or
+// the timeout expires.
+func waitForServerState(ctx context.Context, client *kubeberth.Client, name string, want string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(serverPollInterval)
+	defer ticker.Stop()
+
+	for {
+		server, err := client.GetServer(ctx, name)
+		if err != nil {
+			return err
+		}
+		if server.State == want {
+			return nil
+		}
+		tflog.Info(ctx, fmt.Sprintf("waiting for server %q to become %s, currently %s", name, want, server.State))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for server %q to become %s, currently %s", timeout, name, want, server.State)
+		case <-ticker.C:
+		}
+	}
+}
+
+// setServerRunning changes the power state of a server through UpdateServer
+// and waits for the change to take effect. The caller must hold the server
+// lock.
+func setServerRunning(ctx context.Context, client *kubeberth.Client, name string, running bool, timeout time.Duration) error {
+	err := modifyServer(ctx, client, name, func(server *kubeberth.RequestServer) bool {
+		if server.Running == running {
+			return false
+		}
+		server.Running = running
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	want := serverStateStopped
+	if running {
+		want = serverStateRunning
+	}
+
+	return waitForServerState(ctx, client, name, want, timeout)
+}
+
+func (r serverPowerResource) apply(ctx context.Context, data *serverPowerResourceData, restart bool) error {
+	name := data.Server.Value
+
+	// Hold the server lock until the server reached its new state, so that a
+	// kubeberth_server or kubeberth_disk_attachment applied in parallel does
+	// not write back a stale power state.
+	unlock := r.provider.lockServer(data.Namespace, name)
+	defer unlock()
+
+	if data.State.Value == powerStateStopped {
+		tflog.Info(ctx, fmt.Sprintf("stopping server %q", name))
+		return setServerRunning(ctx, r.provider.namespacedClient(data.Namespace), name, false, data.shutdownTimeout())
+	}
+
+	if restart {
+		tflog.Info(ctx, fmt.Sprintf("restarting server %q", name))
+		if err := setServerRunning(ctx, r.provider.namespacedClient(data.Namespace), name, false, data.shutdownTimeout()); err != nil {
+			return err
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("starting server %q", name))
+	return setServerRunning(ctx, r.provider.namespacedClient(data.Namespace), name, true, serverStartTimeout)
+}
+
+func (r serverPowerResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+}
+
+func (r serverPowerResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data serverPowerResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	if err := r.apply(ctx, &data, false); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change power state of server %q, got error: %s", data.Server.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r serverPowerResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data serverPowerResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	responseServer, err := r.provider.namespacedClient(data.Namespace).GetServer(ctx, data.Server.Value)
+	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+		return
+	}
+
+	data.Namespace = refreshNamespace(data.Namespace, responseServer.Namespace)
+	if responseServer.Running {
+		data.State = types.String{Value: powerStateRunning}
+	} else {
+		data.State = types.String{Value: powerStateStopped}
+	}
+
+	tflog.Trace(ctx, "read a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r serverPowerResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state serverPowerResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restart := state.State.Value == powerStateRunning && !stringMapsEqual(state.RestartTrigger, data.RestartTrigger)
+	if err := r.apply(ctx, &data, restart); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change power state of server %q, got error: %s", data.Server.Value, err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r serverPowerResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	tflog.Trace(ctx, "deleted a resource, leaving the server power state unchanged")
+}
+
+func (r serverPowerResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("server"), req, resp)
+}
+
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if v, ok := b[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
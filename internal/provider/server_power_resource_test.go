@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccServerPowerResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerPowerResourceConfig("running", "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server_power.test", "state", "running"),
+				),
+			},
+			// Restart testing
+			{
+				Config: testAccServerPowerResourceConfig("running", "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server_power.test", "restart_trigger.revision", "two"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "kubeberth_server_power.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportStateIdFunc("kubeberth_server_power.test", "server"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"shutdown_timeout", "restart_trigger"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerPowerResourceConfig("stopped", "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server_power.test", "state", "stopped"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerPowerResourceConfig(state string, revision string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-server-power"
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-server-power"
+}
+
+resource "kubeberth_server_power" "test" {
+  server           = kubeberth_server.test.name
+  state            = %[1]q
+  shutdown_timeout = "1m"
+
+  restart_trigger = {
+    revision = %[2]q
+  }
+}
+`, state, revision)
+}
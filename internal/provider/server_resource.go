@@ -3,7 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/kubeberth/kubeberth-go"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,14 +30,14 @@ func (t serverResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: addNamespaceAttribute(addLabelAttributes(map[string]tfsdk.Attribute{
 			"name": {
 				MarkdownDescription: "name",
 				Type:                types.StringType,
 				Required:            true,
 			},
 			"running": {
-				MarkdownDescription: "running",
+				MarkdownDescription: "running. Leave unset when the power state is managed by `kubeberth_server_power`.",
 				Type:                types.BoolType,
 				Optional:            true,
 			},
@@ -43,26 +46,214 @@ func (t serverResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 				Type:                types.Int64Type,
 				Required:            true,
 			},
+			"cpu_topology": {
+				MarkdownDescription: "How the `cpu` vCPUs are presented to the guest. `sockets * cores * threads` must equal `cpu`.",
+				Optional:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"sockets": {
+						MarkdownDescription: "sockets",
+						Type:                types.Int64Type,
+						Required:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64Between(1, math.MaxUint32),
+						},
+					},
+					"cores": {
+						MarkdownDescription: "Cores per socket.",
+						Type:                types.Int64Type,
+						Required:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64Between(1, math.MaxUint32),
+						},
+					},
+					"threads": {
+						MarkdownDescription: "Threads per core.",
+						Type:                types.Int64Type,
+						Required:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64Between(1, math.MaxUint32),
+						},
+					},
+				}),
+			},
+			"cpu_model": {
+				MarkdownDescription: "CPU model exposed to the guest, e.g. `host-passthrough`, `host-model` or a named model such as `Skylake-Server`.",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"dedicated_cpu": {
+				MarkdownDescription: "Pin each vCPU to a dedicated host CPU. Requires nodes with the static CPU manager policy.",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
+			"firmware": {
+				MarkdownDescription: "Firmware to boot the guest with, `bios` or `uefi`. Defaults to the operator default.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(firmwareBIOS, firmwareUEFI),
+				},
+			},
+			"secure_boot": {
+				MarkdownDescription: "Enable UEFI secure boot. Requires `firmware = \"uefi\"`.",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
+			"tpm": {
+				MarkdownDescription: "Attach a virtual TPM to the guest.",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
 			"memory": {
 				MarkdownDescription: "memory",
 				Type:                types.StringType,
 				Required:            true,
+				Validators: []tfsdk.AttributeValidator{
+					quantityString(),
+				},
+			},
+			"memory_request": {
+				MarkdownDescription: "Memory requested for the server pod when it is less than `memory`, to overcommit the node. Defaults to `memory`.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					quantityString(),
+				},
+			},
+			"hugepages": {
+				MarkdownDescription: "Back guest memory with hugepages of this size, `2Mi` or `1Gi`. `memory` must be a multiple of the page size.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(hugePages2Mi, hugePages1Gi),
+				},
 			},
 			"mac_address": {
-				MarkdownDescription: "mac_address",
+				MarkdownDescription: "mac_address. Shorthand for the `mac_address` of the first entry of `network_interfaces`. Assigned by kubeberth, or derived from `mac_address_seed`, when unset.",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Validators: []tfsdk.AttributeValidator{
+					macAddress(),
+				},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					macAddressPlanModifier(),
+				},
+			},
+			"mac_address_seed": {
+				MarkdownDescription: "Derive `mac_address` from this seed and the server name when it is not set, so that the server keeps its MAC address when it is recreated. Use a different seed per environment sharing a network.",
 				Type:                types.StringType,
 				Optional:            true,
 			},
+			"network_interfaces": {
+				MarkdownDescription: "Network interfaces of the server, in the order they are attached to the guest. Defaults to a single interface on the pod network.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"network": {
+						MarkdownDescription: "Name of the network, or of the network attachment definition, to connect the interface to.",
+						Type:                types.StringType,
+						Required:            true,
+					},
+					"mac_address": {
+						MarkdownDescription: "MAC address of the interface. Assigned by kubeberth when unset.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							macAddress(),
+						},
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							macAddressPlanModifier(),
+						},
+					},
+					"model": {
+						MarkdownDescription: "Emulated NIC model, `virtio` or `e1000`.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf(nicModelVirtio, nicModelE1000),
+						},
+					},
+					"primary": {
+						MarkdownDescription: "Whether the interface carries the default route and the address reported in `ip_addresses`. At most one interface can be primary.",
+						Type:                types.BoolType,
+						Optional:            true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
 			"hostname": {
 				MarkdownDescription: "hostname",
 				Type:                types.StringType,
 				Required:            true,
 			},
 			"hosting": {
-				MarkdownDescription: "hosting",
+				MarkdownDescription: "Name of the node to place the server on. See the `kubeberth_nodes` data source. Shortcut that conflicts with `node_selector`, `affinity` and `anti_affinity`.",
 				Type:                types.StringType,
 				Optional:            true,
 			},
+			"migration_strategy": {
+				MarkdownDescription: "How a running server is moved when `hosting` changes: `live` migrates it without downtime, `restart` stops it, moves it and starts it again. Defaults to `live`.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(migrationStrategyLive, migrationStrategyRestart),
+				},
+			},
+			"migration_timeout": {
+				MarkdownDescription: "How long to wait for a server to be moved to another node. Defaults to `30m`.",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					durationString(),
+				},
+			},
+			"node_selector": {
+				MarkdownDescription: "Only place the server on nodes carrying all of these labels.",
+				Type:                types.MapType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"tolerations": {
+				MarkdownDescription: "Node taints the server tolerates. Can be combined with `hosting` to place the server on a tainted node.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"key": {
+						MarkdownDescription: "Taint key. Empty with operator `Exists` tolerates every taint.",
+						Type:                types.StringType,
+						Optional:            true,
+					},
+					"operator": {
+						MarkdownDescription: "`Equal` or `Exists`. Defaults to `Equal`.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf(tolerationOpEqual, tolerationOpExists),
+						},
+					},
+					"value": {
+						MarkdownDescription: "Taint value, must be empty with operator `Exists`.",
+						Type:                types.StringType,
+						Optional:            true,
+					},
+					"effect": {
+						MarkdownDescription: "Taint effect to tolerate, `NoSchedule`, `PreferNoSchedule` or `NoExecute`. Tolerates all effects when unset.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf(taintEffectNoSchedule, taintEffectPreferNoSchedule, taintEffectNoExecute),
+						},
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+			"affinity": {
+				MarkdownDescription: "Place the server on nodes with the given labels, or next to the given servers.",
+				Optional:            true,
+				Attributes:          tfsdk.SingleNestedAttributes(affinityAttributes()),
+			},
+			"anti_affinity": {
+				MarkdownDescription: "Keep the server off nodes with the given labels, or away from the given servers, e.g. the other members of a cluster.",
+				Optional:            true,
+				Attributes:          tfsdk.SingleNestedAttributes(affinityAttributes()),
+			},
 			"disks": {
 				MarkdownDescription: "disks",
 				Optional:            true,
@@ -71,8 +262,42 @@ func (t serverResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 						Type:     types.StringType,
 						Required: true,
 					},
+					"boot_order": {
+						MarkdownDescription: "Boot priority of the disk, lowest first. Disks without a boot order are not booted from.",
+						Type:                types.Int64Type,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64Between(1, math.MaxInt32),
+						},
+					},
+					"bus": {
+						MarkdownDescription: "Bus the disk is attached to, one of `virtio`, `sata` or `scsi`.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf(diskBusVirtio, diskBusSATA, diskBusSCSI),
+						},
+					},
+					"read_only": {
+						MarkdownDescription: "Attach the disk read-only.",
+						Type:                types.BoolType,
+						Optional:            true,
+					},
+					"serial": {
+						MarkdownDescription: "Serial number exposed to the guest, e.g. under `/dev/disk/by-id`.",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringMatches(diskSerialRegexp, "serial must be 1 to 20 letters, digits, `-` or `_`"),
+						},
+					},
 				}, tfsdk.ListNestedAttributesOptions{}),
 			},
+			"exclusive_disks": {
+				MarkdownDescription: "Whether `disks` is the complete list of attached disks. Set to `false` to keep disks attached by `kubeberth_disk_attachment` resources. Defaults to `true`.",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
 			"isoimage": {
 				MarkdownDescription: "isoimage",
 				Optional:            true,
@@ -93,7 +318,7 @@ func (t serverResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 					},
 				}),
 			},
-		},
+		})),
 	}, nil
 }
 
@@ -105,8 +330,271 @@ func (t serverResourceType) NewResource(ctx context.Context, in tfsdk.Provider)
 	}, diags
 }
 
+const (
+	diskBusVirtio = "virtio"
+	diskBusSATA   = "sata"
+	diskBusSCSI   = "scsi"
+)
+
+var diskSerialRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,20}$`)
+
 type diskData struct {
-	Name types.String `tfsdk:"name"`
+	Name      types.String `tfsdk:"name"`
+	BootOrder types.Int64  `tfsdk:"boot_order"`
+	Bus       types.String `tfsdk:"bus"`
+	ReadOnly  types.Bool   `tfsdk:"read_only"`
+	Serial    types.String `tfsdk:"serial"`
+}
+
+func newAttachedDisk(disk *diskData) kubeberth.AttachedDisk {
+	attachedDisk := kubeberth.AttachedDisk{
+		Name:     disk.Name.Value,
+		Bus:      disk.Bus.Value,
+		ReadOnly: disk.ReadOnly.Value,
+		Serial:   disk.Serial.Value,
+	}
+
+	if !disk.BootOrder.Null {
+		bootOrder := uint(disk.BootOrder.Value)
+		attachedDisk.BootOrder = &bootOrder
+	}
+
+	return attachedDisk
+}
+
+// newDiskData converts an attached disk reported by kubeberth. Attributes the
+// API leaves empty are null.
+func newDiskData(disk *kubeberth.AttachedDisk) diskData {
+	data := diskData{
+		Name:      types.String{Value: disk.Name},
+		BootOrder: types.Int64{Null: true},
+		Bus:       types.String{Null: disk.Bus == "", Value: disk.Bus},
+		ReadOnly:  types.Bool{Value: disk.ReadOnly},
+		Serial:    types.String{Null: disk.Serial == "", Value: disk.Serial},
+	}
+
+	if disk.BootOrder != nil {
+		data.BootOrder = types.Int64{Value: int64(*disk.BootOrder)}
+	}
+
+	return data
+}
+
+// refreshDiskData updates the attributes of disk that are set in the
+// configuration from what kubeberth reports, leaving unset attributes null.
+func refreshDiskData(disk diskData, attachedDisk *kubeberth.AttachedDisk) diskData {
+	current := newDiskData(attachedDisk)
+
+	if !disk.BootOrder.Null {
+		disk.BootOrder = current.BootOrder
+	}
+	if !disk.Bus.Null {
+		disk.Bus = current.Bus
+	}
+	if !disk.ReadOnly.Null {
+		disk.ReadOnly = current.ReadOnly
+	}
+	if !disk.Serial.Null {
+		disk.Serial = current.Serial
+	}
+
+	return disk
+}
+
+const (
+	nicModelVirtio = "virtio"
+	nicModelE1000  = "e1000"
+)
+
+type networkInterfaceData struct {
+	Network    types.String `tfsdk:"network"`
+	MACAddress types.String `tfsdk:"mac_address"`
+	Model      types.String `tfsdk:"model"`
+	Primary    types.Bool   `tfsdk:"primary"`
+}
+
+func newNetworkInterface(nic *networkInterfaceData) kubeberth.NetworkInterface {
+	return kubeberth.NetworkInterface{
+		Network:    nic.Network.Value,
+		MACAddress: normalizeMACAddress(nic.MACAddress.Value),
+		Model:      nic.Model.Value,
+		Primary:    nic.Primary.Value,
+	}
+}
+
+// newNetworkInterfaceData converts a network interface reported by kubeberth.
+// Attributes the API leaves empty are null.
+func newNetworkInterfaceData(nic *kubeberth.NetworkInterface) networkInterfaceData {
+	return networkInterfaceData{
+		Network:    types.String{Value: nic.Network},
+		MACAddress: macAddressValue(nic.MACAddress),
+		Model:      types.String{Null: nic.Model == "", Value: nic.Model},
+		Primary:    types.Bool{Value: nic.Primary},
+	}
+}
+
+// refreshNetworkInterfaces updates the interfaces in state from the interfaces
+// of the server. Only attributes that are set are refreshed, interfaces that
+// no longer exist are dropped and interfaces added outside of Terraform are
+// appended so that they show up as drift.
+func refreshNetworkInterfaces(nics []networkInterfaceData, current []kubeberth.NetworkInterface) []networkInterfaceData {
+	if nics == nil {
+		return nil
+	}
+
+	refreshed := []networkInterfaceData{}
+	known := map[int]bool{}
+	for _, nic := range nics {
+		for i := range current {
+			if known[i] || current[i].Network != nic.Network.Value {
+				continue
+			}
+
+			currentNIC := newNetworkInterfaceData(&current[i])
+			if !nic.MACAddress.Null {
+				nic.MACAddress = currentNIC.MACAddress
+			}
+			if !nic.Model.Null {
+				nic.Model = currentNIC.Model
+			}
+			if !nic.Primary.Null {
+				nic.Primary = currentNIC.Primary
+			}
+
+			refreshed = append(refreshed, nic)
+			known[i] = true
+			break
+		}
+	}
+
+	for i := range current {
+		if !known[i] {
+			refreshed = append(refreshed, newNetworkInterfaceData(&current[i]))
+		}
+	}
+
+	return refreshed
+}
+
+const (
+	tolerationOpEqual  = "Equal"
+	tolerationOpExists = "Exists"
+
+	taintEffectNoSchedule       = "NoSchedule"
+	taintEffectPreferNoSchedule = "PreferNoSchedule"
+	taintEffectNoExecute        = "NoExecute"
+)
+
+// affinityAttributes returns the attributes shared by the affinity and
+// anti_affinity blocks.
+func affinityAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"node_labels": {
+			MarkdownDescription: "Labels of the nodes.",
+			Type:                types.MapType{ElemType: types.StringType},
+			Optional:            true,
+		},
+		"servers": {
+			MarkdownDescription: "Names of the other servers.",
+			Type:                types.ListType{ElemType: types.StringType},
+			Optional:            true,
+		},
+		"required": {
+			MarkdownDescription: "Whether the rule must be met to schedule the server, or is only preferred. Defaults to `true`.",
+			Type:                types.BoolType,
+			Optional:            true,
+		},
+	}
+}
+
+type tolerationData struct {
+	Key      types.String `tfsdk:"key"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+	Effect   types.String `tfsdk:"effect"`
+}
+
+func newTolerationData(toleration *kubeberth.Toleration) tolerationData {
+	return tolerationData{
+		Key:      types.String{Null: toleration.Key == "", Value: toleration.Key},
+		Operator: types.String{Null: toleration.Operator == "", Value: toleration.Operator},
+		Value:    types.String{Null: toleration.Value == "", Value: toleration.Value},
+		Effect:   types.String{Null: toleration.Effect == "", Value: toleration.Effect},
+	}
+}
+
+type affinityData struct {
+	NodeLabels map[string]string `tfsdk:"node_labels"`
+	Servers    []string          `tfsdk:"servers"`
+	Required   types.Bool        `tfsdk:"required"`
+}
+
+func newAffinity(data *affinityData) *kubeberth.Affinity {
+	if data == nil {
+		return nil
+	}
+
+	return &kubeberth.Affinity{
+		NodeLabels: data.NodeLabels,
+		Servers:    data.Servers,
+		Required:   data.Required.Null || data.Required.Value,
+	}
+}
+
+func newAffinityData(affinity *kubeberth.Affinity) *affinityData {
+	return &affinityData{
+		NodeLabels: affinity.NodeLabels,
+		Servers:    affinity.Servers,
+		Required:   types.Bool{Value: affinity.Required},
+	}
+}
+
+// refreshAffinityData updates the affinity in state from what kubeberth
+// reports, leaving attributes that are not set null.
+func refreshAffinityData(data *affinityData, affinity *kubeberth.Affinity) *affinityData {
+	if data == nil {
+		return nil
+	}
+	if affinity == nil {
+		return &affinityData{Required: data.Required}
+	}
+
+	refreshed := &affinityData{Required: data.Required}
+	if data.NodeLabels != nil || len(affinity.NodeLabels) > 0 {
+		refreshed.NodeLabels = affinity.NodeLabels
+	}
+	if data.Servers != nil || len(affinity.Servers) > 0 {
+		refreshed.Servers = affinity.Servers
+	}
+	if !data.Required.Null || !affinity.Required {
+		refreshed.Required = types.Bool{Value: affinity.Required}
+	}
+
+	return refreshed
+}
+
+const (
+	hugePages2Mi = "2Mi"
+	hugePages1Gi = "1Gi"
+)
+
+const (
+	firmwareBIOS = "bios"
+	firmwareUEFI = "uefi"
+)
+
+type cpuTopologyData struct {
+	Sockets types.Int64 `tfsdk:"sockets"`
+	Cores   types.Int64 `tfsdk:"cores"`
+	Threads types.Int64 `tfsdk:"threads"`
+}
+
+func newCPUTopologyData(topology *kubeberth.CPUTopology) *cpuTopologyData {
+	return &cpuTopologyData{
+		Sockets: types.Int64{Value: int64(topology.Sockets)},
+		Cores:   types.Int64{Value: int64(topology.Cores)},
+		Threads: types.Int64{Value: int64(topology.Threads)},
+	}
 }
 
 type isoimageData struct {
@@ -118,16 +606,53 @@ type cloudinitData struct {
 }
 
 type serverResourceData struct {
-	Name       types.String   `tfsdk:"name"`
-	Running    types.Bool     `tfsdk:"running"`
-	CPU        types.Int64    `tfsdk:"cpu"`
-	Memory     types.String   `tfsdk:"memory"`
-	MACAddress types.String   `tfsdk:"mac_address"`
-	Hostname   types.String   `tfsdk:"hostname"`
-	Hosting    types.String   `tfsdk:"hosting"`
-	Disks      []diskData     `tfsdk:"disks"`
-	ISOImage   *isoimageData  `tfsdk:"isoimage"`
-	CloudInit  *cloudinitData `tfsdk:"cloudinit"`
+	Name           types.String   `tfsdk:"name"`
+	Running        types.Bool     `tfsdk:"running"`
+	CPU            types.Int64    `tfsdk:"cpu"`
+	CPUModel       types.String   `tfsdk:"cpu_model"`
+	DedicatedCPU   types.Bool     `tfsdk:"dedicated_cpu"`
+	Firmware       types.String   `tfsdk:"firmware"`
+	SecureBoot     types.Bool     `tfsdk:"secure_boot"`
+	TPM            types.Bool     `tfsdk:"tpm"`
+	Memory         types.String   `tfsdk:"memory"`
+	MemoryRequest  types.String   `tfsdk:"memory_request"`
+	HugePages      types.String   `tfsdk:"hugepages"`
+	MACAddress     types.String   `tfsdk:"mac_address"`
+	MACAddressSeed types.String   `tfsdk:"mac_address_seed"`
+	Hostname       types.String   `tfsdk:"hostname"`
+	Hosting        types.String   `tfsdk:"hosting"`
+	Disks          []diskData     `tfsdk:"disks"`
+	ExclusiveDisks types.Bool     `tfsdk:"exclusive_disks"`
+	ISOImage       *isoimageData  `tfsdk:"isoimage"`
+	CloudInit      *cloudinitData `tfsdk:"cloudinit"`
+
+	NetworkInterfaces []networkInterfaceData `tfsdk:"network_interfaces"`
+	CPUTopology       *cpuTopologyData       `tfsdk:"cpu_topology"`
+	Tolerations       []tolerationData       `tfsdk:"tolerations"`
+	Affinity          *affinityData          `tfsdk:"affinity"`
+	AntiAffinity      *affinityData          `tfsdk:"anti_affinity"`
+	NodeSelector      map[string]string      `tfsdk:"node_selector"`
+	MigrationStrategy types.String           `tfsdk:"migration_strategy"`
+	MigrationTimeout  types.String           `tfsdk:"migration_timeout"`
+
+	Namespace   types.String      `tfsdk:"namespace"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+	AllLabels   types.Map         `tfsdk:"all_labels"`
+}
+
+func (data *serverResourceData) migrationTimeout() time.Duration {
+	if data.MigrationTimeout.Null || data.MigrationTimeout.Unknown {
+		return defaultMigrationTimeout
+	}
+
+	// Already checked by the attribute validator.
+	timeout, err := time.ParseDuration(data.MigrationTimeout.Value)
+	if err != nil {
+		return defaultMigrationTimeout
+	}
+
+	return timeout
 }
 
 type serverResource struct {
@@ -138,8 +663,8 @@ func newRequestServer(data *serverResourceData) *kubeberth.RequestServer {
 	cpu    := resource.MustParse(strconv.FormatInt(data.CPU.Value, 10))
 	memory := resource.MustParse(data.Memory.Value)
 	disks  := []kubeberth.AttachedDisk{}
-	for _, disk := range data.Disks {
-		disks = append(disks, kubeberth.AttachedDisk{Name: disk.Name.Value})
+	for i := range data.Disks {
+		disks = append(disks, newAttachedDisk(&data.Disks[i]))
 	}
 
 	server := &kubeberth.RequestServer{
@@ -147,12 +672,55 @@ func newRequestServer(data *serverResourceData) *kubeberth.RequestServer {
 		Running:    data.Running.Value,
 		CPU:        &cpu,
 		Memory:     &memory,
-		MACAddress: data.MACAddress.Value,
+		MACAddress: normalizeMACAddress(data.MACAddress.Value),
 		Hostname:   data.Hostname.Value,
 		Hosting:    data.Hosting.Value,
 		Disks:      disks,
 	}
 
+	server.NodeSelector = data.NodeSelector
+	server.Affinity = newAffinity(data.Affinity)
+	server.AntiAffinity = newAffinity(data.AntiAffinity)
+	for _, toleration := range data.Tolerations {
+		server.Tolerations = append(server.Tolerations, kubeberth.Toleration{
+			Key:      toleration.Key.Value,
+			Operator: toleration.Operator.Value,
+			Value:    toleration.Value.Value,
+			Effect:   toleration.Effect.Value,
+		})
+	}
+
+	server.HugePages = data.HugePages.Value
+	if !data.MemoryRequest.Null {
+		memoryRequest := resource.MustParse(data.MemoryRequest.Value)
+		server.MemoryRequest = &memoryRequest
+	}
+
+	server.CPUModel = data.CPUModel.Value
+	server.DedicatedCPU = data.DedicatedCPU.Value
+	server.Firmware = data.Firmware.Value
+	server.SecureBoot = data.SecureBoot.Value
+	server.TPM = data.TPM.Value
+	if data.CPUTopology != nil {
+		server.CPUTopology = &kubeberth.CPUTopology{
+			Sockets: uint32(data.CPUTopology.Sockets.Value),
+			Cores:   uint32(data.CPUTopology.Cores.Value),
+			Threads: uint32(data.CPUTopology.Threads.Value),
+		}
+	}
+
+	// mac_address is shorthand for the MAC address of the first interface.
+	for i := range data.NetworkInterfaces {
+		nic := newNetworkInterface(&data.NetworkInterfaces[i])
+		if i == 0 && nic.MACAddress == "" {
+			nic.MACAddress = server.MACAddress
+		}
+		server.NetworkInterfaces = append(server.NetworkInterfaces, nic)
+	}
+	if len(server.NetworkInterfaces) > 0 {
+		server.MACAddress = server.NetworkInterfaces[0].MACAddress
+	}
+
 	if data.ISOImage != nil {
 		if !data.ISOImage.Name.Null {
 			server.ISOImage = &kubeberth.AttachedISOImage{Name: data.ISOImage.Name.Value}
@@ -168,10 +736,540 @@ func newRequestServer(data *serverResourceData) *kubeberth.RequestServer {
 	return server
 }
 
+// newRequestServerFromResponse rebuilds the request for an existing server so
+// that a single field can be changed without touching the others.
+func newRequestServerFromResponse(server *kubeberth.ResponseServer) (*kubeberth.RequestServer, error) {
+	cpu, err := resource.ParseQuantity(server.CPU)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpu %q: %w", server.CPU, err)
+	}
+
+	memory, err := resource.ParseQuantity(server.Memory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory %q: %w", server.Memory, err)
+	}
+
+	var memoryRequest *resource.Quantity
+	if server.MemoryRequest != "" {
+		quantity, err := resource.ParseQuantity(server.MemoryRequest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory request %q: %w", server.MemoryRequest, err)
+		}
+		memoryRequest = &quantity
+	}
+
+	return &kubeberth.RequestServer{
+		Name:       server.Name,
+		Running:    server.Running,
+		CPU:        &cpu,
+		Memory:     &memory,
+		MACAddress: server.MACAddress,
+		Hostname:   server.Hostname,
+		Hosting:    server.Hosting,
+		Disks:      append([]kubeberth.AttachedDisk{}, server.Disks...),
+		ISOImage:   server.ISOImage,
+		CloudInit:  server.CloudInit,
+
+		NetworkInterfaces: append([]kubeberth.NetworkInterface{}, server.NetworkInterfaces...),
+		CPUTopology:       server.CPUTopology,
+		CPUModel:          server.CPUModel,
+		DedicatedCPU:      server.DedicatedCPU,
+		Firmware:          server.Firmware,
+		SecureBoot:        server.SecureBoot,
+		TPM:               server.TPM,
+		MemoryRequest:     memoryRequest,
+		HugePages:         server.HugePages,
+		NodeSelector:      server.NodeSelector,
+		Tolerations:       append([]kubeberth.Toleration{}, server.Tolerations...),
+		Affinity:          server.Affinity,
+		AntiAffinity:      server.AntiAffinity,
+		Labels:            server.Labels,
+		Annotations:       server.Annotations,
+	}, nil
+}
+
+// mergeAttachedDisks returns the planned disks followed by the currently
+// attached disks that were never managed by the server resource itself.
+func mergeAttachedDisks(current []kubeberth.AttachedDisk, previous []diskData, planned []kubeberth.AttachedDisk) []kubeberth.AttachedDisk {
+	managed := map[string]bool{}
+	for _, disk := range previous {
+		managed[disk.Name.Value] = true
+	}
+	for _, disk := range planned {
+		managed[disk.Name] = true
+	}
+
+	disks := append([]kubeberth.AttachedDisk{}, planned...)
+	for _, disk := range current {
+		if !managed[disk.Name] {
+			disks = append(disks, disk)
+		}
+	}
+
+	return disks
+}
+
+// refreshDisks updates the disks in state from the disks attached to the
+// server. Detached disks are dropped and, when the list is exclusive, disks
+// attached outside of Terraform are added so that they show up as drift.
+func refreshDisks(disks []diskData, attached []kubeberth.AttachedDisk, exclusive bool) []diskData {
+	current := map[string]*kubeberth.AttachedDisk{}
+	for i := range attached {
+		current[attached[i].Name] = &attached[i]
+	}
+
+	refreshed := []diskData{}
+	known := map[string]bool{}
+	for _, disk := range disks {
+		attachedDisk, ok := current[disk.Name.Value]
+		if !ok {
+			continue
+		}
+		refreshed = append(refreshed, refreshDiskData(disk, attachedDisk))
+		known[disk.Name.Value] = true
+	}
+
+	if exclusive {
+		for i := range attached {
+			if !known[attached[i].Name] {
+				refreshed = append(refreshed, newDiskData(&attached[i]))
+			}
+		}
+	}
+
+	if disks == nil && len(refreshed) == 0 {
+		return nil
+	}
+
+	return refreshed
+}
+
+func (r serverResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	validateServerDisks(ctx, req.Config, &resp.Diagnostics)
+	validateServerNetworkInterfaces(ctx, req.Config, &resp.Diagnostics)
+	validateServerMACAddressSeed(ctx, req.Config, &resp.Diagnostics)
+	validateServerCPUTopology(ctx, req.Config, &resp.Diagnostics)
+	validateServerFirmware(ctx, req.Config, &resp.Diagnostics)
+	validateServerMemory(ctx, req.Config, &resp.Diagnostics)
+	validateServerScheduling(ctx, req.Config, &resp.Diagnostics)
+}
+
+// validateServerScheduling rejects hosting together with the scheduling
+// constraints it is a shortcut for, and tolerations with a value that
+// operator Exists would ignore.
+func validateServerScheduling(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var hosting types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("hosting"), &hosting)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if !hosting.Null {
+		var nodeSelector types.Map
+		var affinity, antiAffinity types.Object
+		diags = config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("node_selector"), &nodeSelector)
+		diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("affinity"), &affinity)...)
+		diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("anti_affinity"), &antiAffinity)...)
+		diagnostics.Append(diags...)
+
+		if diags.HasError() {
+			return
+		}
+
+		conflicts := []struct {
+			name string
+			null bool
+		}{
+			{"node_selector", nodeSelector.Null},
+			{"affinity", affinity.Null},
+			{"anti_affinity", antiAffinity.Null},
+		}
+		for _, conflict := range conflicts {
+			if !conflict.null {
+				diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName(conflict.name), "Conflicting Attributes",
+					fmt.Sprintf("hosting places the server on a single node and cannot be combined with %s.", conflict.name))
+			}
+		}
+	}
+
+	var tolerations types.List
+	diags = config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("tolerations"), &tolerations)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || tolerations.Null || tolerations.Unknown {
+		return
+	}
+
+	var data []tolerationData
+	diags = tolerations.ElementsAs(ctx, &data, false)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	for i, toleration := range data {
+		if toleration.Operator.Value == tolerationOpExists && !toleration.Value.Null && !toleration.Value.Unknown && toleration.Value.Value != "" {
+			diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("tolerations").WithElementKeyInt(i).WithAttributeName("value"), "Invalid Toleration",
+				"value must be empty when operator is \"Exists\".")
+		}
+		if toleration.Operator.Value != tolerationOpExists && !toleration.Key.Unknown && toleration.Key.Value == "" {
+			diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("tolerations").WithElementKeyInt(i).WithAttributeName("key"), "Invalid Toleration",
+				"key can only be empty when operator is \"Exists\".")
+		}
+	}
+}
+
+// quantitiesEqual reports whether a and b are the same quantity, ignoring
+// notation, e.g. `1Gi` and `1024Mi`.
+func quantitiesEqual(a, b string) bool {
+	x, err := resource.ParseQuantity(a)
+	if err != nil {
+		return a == b
+	}
+
+	y, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+
+	return x.Cmp(y) == 0
+}
+
+// refreshBool returns the value kubeberth reports for an optional boolean
+// that is set in state. An imported one is only set if true, as false is the
+// default an unset attribute stands for.
+func refreshBool(value types.Bool, current bool, imported bool) types.Bool {
+	if !value.Null || (imported && current) {
+		return types.Bool{Value: current}
+	}
+
+	return value
+}
+
+// validateServerMemory checks that memory_request does not exceed memory and
+// that memory is a whole number of hugepages. Invalid quantities are left to
+// the attribute validators.
+func validateServerMemory(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var memory, memoryRequest, hugePages types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("memory"), &memory)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("memory_request"), &memoryRequest)...)
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("hugepages"), &hugePages)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	guestMemory, err := parseOptionalQuantity(memory)
+	if err != nil || guestMemory == nil {
+		return
+	}
+
+	if request, err := parseOptionalQuantity(memoryRequest); err == nil && request != nil && request.Cmp(*guestMemory) > 0 {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("memory_request"), "Invalid Memory Request",
+			fmt.Sprintf("memory_request %s exceeds the guest memory %s.", memoryRequest.Value, memory.Value))
+	}
+
+	if pageSize, err := parseOptionalQuantity(hugePages); err == nil && pageSize != nil && guestMemory.Value()%pageSize.Value() != 0 {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("memory"), "Invalid Memory",
+			fmt.Sprintf("memory %s is not a multiple of the %s hugepage size.", memory.Value, hugePages.Value))
+	}
+}
+
+// validateServerFirmware rejects secure boot without UEFI firmware.
+func validateServerFirmware(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var firmware types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("firmware"), &firmware)
+
+	var secureBoot types.Bool
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("secure_boot"), &secureBoot)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || secureBoot.Unknown || !secureBoot.Value || firmware.Unknown {
+		return
+	}
+
+	if firmware.Value != firmwareUEFI {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("secure_boot"), "Secure Boot Requires UEFI",
+			"secure_boot can only be enabled together with firmware = \"uefi\".")
+	}
+}
+
+// validateServerCPUTopology checks that cpu_topology adds up to cpu.
+func validateServerCPUTopology(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var cpu types.Int64
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("cpu"), &cpu)
+
+	var topology types.Object
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("cpu_topology"), &topology)...)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || cpu.Null || cpu.Unknown || topology.Null || topology.Unknown {
+		return
+	}
+
+	var data cpuTopologyData
+	diags = topology.As(ctx, &data, types.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || data.Sockets.Unknown || data.Cores.Unknown || data.Threads.Unknown {
+		return
+	}
+
+	if total := data.Sockets.Value * data.Cores.Value * data.Threads.Value; total != cpu.Value {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("cpu_topology"), "Invalid CPU Topology",
+			fmt.Sprintf("%d sockets * %d cores * %d threads is %d vCPUs, but cpu is %d.", data.Sockets.Value, data.Cores.Value, data.Threads.Value, total, cpu.Value))
+	}
+}
+
+// validateServerDisks rejects disks sharing a boot order or a serial.
+func validateServerDisks(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var disks types.List
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("disks"), &disks)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || disks.Null || disks.Unknown {
+		return
+	}
+
+	var data []diskData
+	diags = disks.ElementsAs(ctx, &data, false)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	bootOrders := map[int64]string{}
+	serials := map[string]string{}
+	for i, disk := range data {
+		path := tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(i)
+
+		if !disk.BootOrder.Null && !disk.BootOrder.Unknown {
+			if other, ok := bootOrders[disk.BootOrder.Value]; ok {
+				diagnostics.AddAttributeError(path.WithAttributeName("boot_order"), "Duplicate Boot Order",
+					fmt.Sprintf("Disks %q and %q both have boot_order %d.", other, disk.Name.Value, disk.BootOrder.Value))
+			}
+			bootOrders[disk.BootOrder.Value] = disk.Name.Value
+		}
+
+		if !disk.Serial.Null && !disk.Serial.Unknown {
+			if other, ok := serials[disk.Serial.Value]; ok {
+				diagnostics.AddAttributeError(path.WithAttributeName("serial"), "Duplicate Serial",
+					fmt.Sprintf("Disks %q and %q both have serial %q.", other, disk.Name.Value, disk.Serial.Value))
+			}
+			serials[disk.Serial.Value] = disk.Name.Value
+		}
+	}
+}
+
+// validateServerNetworkInterfaces rejects duplicate MAC addresses, more than
+// one primary interface, and a mac_address that contradicts the first
+// interface.
+func validateServerNetworkInterfaces(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var macAddress types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address"), &macAddress)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	var nics types.List
+	diags = config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("network_interfaces"), &nics)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || nics.Null || nics.Unknown {
+		return
+	}
+
+	var data []networkInterfaceData
+	diags = nics.ElementsAs(ctx, &data, false)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	macAddresses := map[string]int{}
+	primary := -1
+	for i, nic := range data {
+		path := tftypes.NewAttributePath().WithAttributeName("network_interfaces").WithElementKeyInt(i)
+
+		mac := nic.MACAddress
+		if i == 0 && !macAddress.Null && !macAddress.Unknown {
+			if !mac.Null && !mac.Unknown && !macAddressesEqual(mac.Value, macAddress.Value) {
+				diagnostics.AddAttributeError(path.WithAttributeName("mac_address"), "Conflicting MAC Address",
+					fmt.Sprintf("mac_address %q is shorthand for the MAC address of the first network interface, which is set to %q.", macAddress.Value, mac.Value))
+			}
+			mac = macAddress
+		}
+
+		if !mac.Null && !mac.Unknown {
+			key := normalizeMACAddress(mac.Value)
+			if other, ok := macAddresses[key]; ok {
+				diagnostics.AddAttributeError(path.WithAttributeName("mac_address"), "Duplicate MAC Address",
+					fmt.Sprintf("Network interfaces %d and %d both have MAC address %q.", other, i, mac.Value))
+			}
+			macAddresses[key] = i
+		}
+
+		if !nic.Primary.Unknown && nic.Primary.Value {
+			if primary >= 0 {
+				diagnostics.AddAttributeError(path.WithAttributeName("primary"), "Multiple Primary Interfaces",
+					fmt.Sprintf("Network interfaces %d and %d are both primary.", primary, i))
+			}
+			primary = i
+		}
+	}
+}
+
+// validateServerMACAddressSeed rejects a mac_address_seed that would never be
+// used because the MAC address of the first interface is set explicitly.
+func validateServerMACAddressSeed(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var seed types.String
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address_seed"), &seed)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() || seed.Null {
+		return
+	}
+
+	var macAddress types.String
+	diags = config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address"), &macAddress)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	firstMACAddress, diags := firstNetworkInterfaceMACAddress(ctx, config)
+	diagnostics.Append(diags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if !macAddress.Null || !firstMACAddress.Null {
+		diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("mac_address_seed"), "Conflicting MAC Address",
+			"mac_address_seed is only used when neither mac_address nor the mac_address of the first network interface is set.")
+	}
+}
+
+// firstNetworkInterfaceMACAddress returns the configured mac_address of the
+// first network interface, null if there is none.
+func firstNetworkInterfaceMACAddress(ctx context.Context, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	var nics types.List
+	diags := config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("network_interfaces"), &nics)
+
+	if diags.HasError() || nics.Null {
+		return types.String{Null: true}, diags
+	}
+	if nics.Unknown {
+		return types.String{Unknown: true}, diags
+	}
+	if len(nics.Elems) == 0 {
+		return types.String{Null: true}, diags
+	}
+
+	var macAddress types.String
+	diags.Append(config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("network_interfaces").WithElementKeyInt(0).WithAttributeName("mac_address"), &macAddress)...)
+
+	return macAddress, diags
+}
+
+func (r serverResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to check when the server is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.planMACAddress(ctx, req, resp)
+	modifyPlanNamespace(ctx, r.provider, req, resp)
+	modifyPlanLabels(ctx, r.provider, req, resp)
+	r.checkHosting(ctx, req, resp)
+}
+
+// planMACAddress fills in mac_address when it is not configured: from the
+// first network interface if that has one, otherwise derived from
+// mac_address_seed. Without either the value assigned by kubeberth is kept.
+func (r serverResource) planMACAddress(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	var macAddress, seed, name types.String
+	diags := req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address"), &macAddress)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address_seed"), &seed)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("name"), &name)
+	resp.Diagnostics.Append(diags...)
+
+	firstMACAddress, diags := firstNetworkInterfaceMACAddress(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || !macAddress.Null {
+		return
+	}
+
+	var planned types.String
+	switch {
+	case firstMACAddress.Unknown:
+		planned = types.String{Unknown: true}
+	case !firstMACAddress.Null:
+		planned = types.String{Value: normalizeMACAddress(firstMACAddress.Value)}
+	case seed.Null:
+		return
+	case seed.Unknown || name.Unknown:
+		planned = types.String{Unknown: true}
+	default:
+		planned = types.String{Value: deriveMACAddress(seed.Value, name.Value)}
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("mac_address"), planned)
+	resp.Diagnostics.Append(diags...)
+}
+
+// checkHosting warns when hosting names a node that does not exist.
+func (r serverResource) checkHosting(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	var hosting types.String
+	diags := req.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("hosting"), &hosting)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.provider.configured || hosting.Null || hosting.Unknown || hosting.Value == "" {
+		return
+	}
+
+	responseNodes, err := r.provider.client.GetAllNodes(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("nodes: %+v\n", responseNodes))
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to list nodes, skipping hosting check: %s", err))
+		return
+	}
+
+	for _, node := range responseNodes {
+		if node.Name == hosting.Value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		tftypes.NewAttributePath().WithAttributeName("hosting"),
+		"Unknown Node",
+		fmt.Sprintf("Node %q does not exist in the cluster. The server will not be scheduled until it does; use the kubeberth_nodes data source to list the available nodes.", hosting.Value),
+	)
+}
+
 func (r serverResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data serverResourceData
 
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -187,13 +1285,23 @@ func (r serverResource) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 	// }
 
 	requestServer := newRequestServer(&data)
-	responseServer, err := r.provider.client.CreateServer(ctx, requestServer)
+	requestServer.Labels, requestServer.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	data.Namespace = r.provider.resolveNamespace(data.Namespace)
+	requestServer.Namespace = data.Namespace.Value
+
+	responseServer, err := r.provider.namespacedClient(data.Namespace).CreateServer(ctx, requestServer)
 	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create server, got error: %s", err))
 		return
 	}
 
+	data.Namespace = refreshNamespace(data.Namespace, responseServer.Namespace)
+
+	if data.MACAddress.Unknown {
+		data.MACAddress = macAddressValue(responseServer.MACAddress)
+	}
+
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	//data.Id = types.String{Value: "example-id"}
@@ -226,13 +1334,93 @@ func (r serverResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 	//     return
 	// }
 
-	responseServer, err := r.provider.client.GetServer(ctx, data.Name.Value)
+	responseServer, err := r.provider.namespacedClient(data.Namespace).GetServer(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
 		return
 	}
 
+	// memory is required, so it is only null right after ImportState. Optional
+	// attributes are then set from whatever kubeberth reports instead of only
+	// being refreshed when they are set.
+	imported := data.Memory.Null
+
+	data.Labels, data.Annotations, data.AllLabels = r.provider.refreshMetadata(ctx, data.Labels, data.Annotations, data.AllLabels, responseServer.Labels, responseServer.Annotations)
+	data.Namespace = refreshNamespace(data.Namespace, responseServer.Namespace)
+
+	data.Disks = refreshDisks(data.Disks, responseServer.Disks, data.ExclusiveDisks.Null || data.ExclusiveDisks.Value)
+	nics := data.NetworkInterfaces
+	if imported && len(responseServer.NetworkInterfaces) > 0 {
+		nics = []networkInterfaceData{}
+	}
+	data.NetworkInterfaces = refreshNetworkInterfaces(nics, responseServer.NetworkInterfaces)
+
+	if !data.Running.Null || imported {
+		data.Running = types.Bool{Value: responseServer.Running}
+	}
+	if cpu, err := resource.ParseQuantity(responseServer.CPU); err == nil {
+		data.CPU = types.Int64{Value: cpu.Value()}
+	}
+	if !quantitiesEqual(data.Memory.Value, responseServer.Memory) {
+		data.Memory = types.String{Value: responseServer.Memory}
+	}
+	data.Hostname = types.String{Value: responseServer.Hostname}
+	if !data.Hosting.Null || imported {
+		data.Hosting = types.String{Null: responseServer.Hosting == "", Value: responseServer.Hosting}
+	}
+	data.ISOImage = nil
+	if responseServer.ISOImage != nil {
+		data.ISOImage = &isoimageData{Name: types.String{Value: responseServer.ISOImage.Name}}
+	}
+	data.CloudInit = nil
+	if responseServer.CloudInit != nil {
+		data.CloudInit = &cloudinitData{Name: types.String{Value: responseServer.CloudInit.Name}}
+	}
+
+	if (data.CPUTopology != nil || imported) && responseServer.CPUTopology != nil {
+		data.CPUTopology = newCPUTopologyData(responseServer.CPUTopology)
+	}
+	if !data.CPUModel.Null || imported {
+		data.CPUModel = types.String{Null: responseServer.CPUModel == "", Value: responseServer.CPUModel}
+	}
+	data.DedicatedCPU = refreshBool(data.DedicatedCPU, responseServer.DedicatedCPU, imported)
+	if (!data.MemoryRequest.Null || imported) && !quantitiesEqual(data.MemoryRequest.Value, responseServer.MemoryRequest) {
+		data.MemoryRequest = types.String{Null: responseServer.MemoryRequest == "", Value: responseServer.MemoryRequest}
+	}
+	if !data.HugePages.Null || imported {
+		data.HugePages = types.String{Null: responseServer.HugePages == "", Value: responseServer.HugePages}
+	}
+	if data.NodeSelector != nil || (imported && len(responseServer.NodeSelector) > 0) {
+		data.NodeSelector = responseServer.NodeSelector
+		if data.NodeSelector == nil {
+			data.NodeSelector = map[string]string{}
+		}
+	}
+	if data.Tolerations != nil || (imported && len(responseServer.Tolerations) > 0) {
+		data.Tolerations = []tolerationData{}
+		for i := range responseServer.Tolerations {
+			data.Tolerations = append(data.Tolerations, newTolerationData(&responseServer.Tolerations[i]))
+		}
+	}
+	if imported && responseServer.Affinity != nil {
+		data.Affinity = &affinityData{Required: types.Bool{Null: true}}
+	}
+	if imported && responseServer.AntiAffinity != nil {
+		data.AntiAffinity = &affinityData{Required: types.Bool{Null: true}}
+	}
+	data.Affinity = refreshAffinityData(data.Affinity, responseServer.Affinity)
+	data.AntiAffinity = refreshAffinityData(data.AntiAffinity, responseServer.AntiAffinity)
+	if !data.Firmware.Null || imported {
+		data.Firmware = types.String{Null: responseServer.Firmware == "", Value: responseServer.Firmware}
+	}
+	data.SecureBoot = refreshBool(data.SecureBoot, responseServer.SecureBoot, imported)
+	data.TPM = refreshBool(data.TPM, responseServer.TPM, imported)
+
+	// Stored in canonical notation; macAddressPlanModifier keeps it when the
+	// configuration uses another one.
+	data.MACAddress = macAddressValue(responseServer.MACAddress)
+
 	tflog.Trace(ctx, "read a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -257,14 +1445,75 @@ func (r serverResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequ
 	//     return
 	// }
 
+	var state serverResourceData
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Hold the server lock for the whole update, including a migration, so
+	// that kubeberth_disk_attachment and kubeberth_server_power resources
+	// applied in parallel neither interleave with it nor see it half done.
+	unlock := r.provider.lockServer(data.Namespace, data.Name.Value)
+	defer unlock()
+
+	// Move a running server to its new node before anything else changes. A
+	// null hosting leaves the placement to the scheduler, so the server stays
+	// where it runs.
+	if migrationPlanned(data.Hosting, state.Hosting, data.Running) {
+		currentServer, err := r.provider.namespacedClient(data.Namespace).GetServer(ctx, data.Name.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+			return
+		}
+
+		if currentServer.Running && currentServer.Hosting != data.Hosting.Value {
+			err = moveServer(ctx, r.provider.namespacedClient(data.Namespace), data.Name.Value, data.Hosting.Value, data.MigrationStrategy.Value, data.migrationTimeout())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to move server %q to %q, got error: %s", data.Name.Value, data.Hosting.Value, err))
+				return
+			}
+		}
+	}
+
 	requestServer := newRequestServer(&data)
-	responseServer, err := r.provider.client.UpdateServer(ctx, data.Name.Value, requestServer)
+
+	// Leave alone what is not managed here: the power state when it is left
+	// to a kubeberth_server_power resource, and disks attached by
+	// kubeberth_disk_attachment resources.
+	exclusiveDisks := data.ExclusiveDisks.Null || data.ExclusiveDisks.Value
+	if data.Running.Null || !exclusiveDisks {
+		currentServer, err := r.provider.namespacedClient(data.Namespace).GetServer(ctx, data.Name.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+			return
+		}
+
+		if data.Running.Null {
+			requestServer.Running = currentServer.Running
+		}
+
+		if !exclusiveDisks {
+			requestServer.Disks = mergeAttachedDisks(currentServer.Disks, state.Disks, requestServer.Disks)
+		}
+	}
+
+	requestServer.Labels, requestServer.Annotations = r.provider.requestLabels(ctx, data.Labels, data.Annotations, &data.AllLabels)
+	requestServer.Namespace = data.Namespace.Value
+
+	responseServer, err := r.provider.namespacedClient(data.Namespace).UpdateServer(ctx, data.Name.Value, requestServer)
 	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", responseServer))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update server, got error: %s", err))
 		return
 	}
 
+	if data.MACAddress.Unknown {
+		data.MACAddress = macAddressValue(responseServer.MACAddress)
+	}
+
 	tflog.Trace(ctx, "updated a resource")
 
 	diags = resp.State.Set(ctx, &data)
@@ -289,7 +1538,7 @@ func (r serverResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 	//     return
 	// }
 
-	ok, err := r.provider.client.DeleteServer(ctx, data.Name.Value)
+	ok, err := r.provider.namespacedClient(data.Namespace).DeleteServer(ctx, data.Name.Value)
 	tflog.Trace(ctx, fmt.Sprintf("server: %+v\n", ok))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete server, got error: %s", err))
@@ -298,5 +1547,5 @@ func (r serverResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 }
 
 func (r serverResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	importNamespacedName(ctx, tftypes.NewAttributePath().WithAttributeName("name"), req, resp)
 }
@@ -1,41 +1,241 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccServerResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccServerResourceConfig(1),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("scaffolding_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "name", "terraform-acc-server"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu", "1"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "scaffolding_example.test",
+				ResourceName:      "kubeberth_server.test",
 				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc("kubeberth_server.test", "name"),
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccServerResourceConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceConfig(cpu int) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-server"
+  running  = false
+  cpu      = %[1]d
+  memory   = "1Gi"
+  hostname = "terraform-acc-server"
+}
+`, cpu)
+}
+
+func TestAccServerResourceMACAddress(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceMACAddressConfig("52-42-00-AA-BB-CC"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "mac_address", "52-42-00-AA-BB-CC"),
+				),
+			},
+			// Refresh stores the canonical notation without planning a change
+			{
+				Config: testAccServerResourceMACAddressConfig("52-42-00-AA-BB-CC"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "mac_address", "52:42:00:aa:bb:cc"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceMACAddressConfig("52:42:00:aa:bb:cd"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "mac_address", "52:42:00:aa:bb:cd"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceMACAddressConfig(macAddress string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name        = "terraform-acc-server-mac"
+  running     = false
+  cpu         = 1
+  memory      = "1Gi"
+  hostname    = "terraform-acc-server-mac"
+  mac_address = %[1]q
+}
+`, macAddress)
+}
+
+func TestMergeAttachedDisks(t *testing.T) {
+	tests := map[string]struct {
+		current  []kubeberth.AttachedDisk
+		previous []string
+		planned  []kubeberth.AttachedDisk
+		want     []kubeberth.AttachedDisk
+	}{
+		"nothing attached": {
+			planned: []kubeberth.AttachedDisk{{Name: "root"}},
+			want:    []kubeberth.AttachedDisk{{Name: "root"}},
+		},
+		"keeps disks attached elsewhere": {
+			current: []kubeberth.AttachedDisk{{Name: "root"}, {Name: "data", ReadOnly: true}},
+			planned: []kubeberth.AttachedDisk{{Name: "root", Bus: diskBusSATA}},
+			want:    []kubeberth.AttachedDisk{{Name: "root", Bus: diskBusSATA}, {Name: "data", ReadOnly: true}},
+		},
+		"drops disks removed from the plan": {
+			current:  []kubeberth.AttachedDisk{{Name: "root"}, {Name: "scratch"}},
+			previous: []string{"root", "scratch"},
+			planned:  []kubeberth.AttachedDisk{{Name: "root"}},
+			want:     []kubeberth.AttachedDisk{{Name: "root"}},
+		},
+		"no planned disks": {
+			current:  []kubeberth.AttachedDisk{{Name: "root"}, {Name: "data"}},
+			previous: []string{"root"},
+			want:     []kubeberth.AttachedDisk{{Name: "data"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var previous []diskData
+			for _, disk := range test.previous {
+				previous = append(previous, diskData{Name: types.String{Value: disk}})
+			}
+
+			got := mergeAttachedDisks(test.current, previous, test.planned)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRefreshDisks(t *testing.T) {
+	bootOrder := uint(1)
+	attached := []kubeberth.AttachedDisk{
+		{Name: "root", BootOrder: &bootOrder, Bus: diskBusSCSI},
+		{Name: "data", ReadOnly: true},
+	}
+	root := diskData{
+		Name:      types.String{Value: "root"},
+		BootOrder: types.Int64{Value: 2},
+		Bus:       types.String{Null: true},
+		ReadOnly:  types.Bool{Null: true},
+		Serial:    types.String{Null: true},
+	}
+	refreshedRoot := diskData{
+		Name:      types.String{Value: "root"},
+		BootOrder: types.Int64{Value: 1},
+		Bus:       types.String{Null: true},
+		ReadOnly:  types.Bool{Null: true},
+		Serial:    types.String{Null: true},
+	}
+	data := diskData{
+		Name:      types.String{Value: "data"},
+		BootOrder: types.Int64{Null: true},
+		Bus:       types.String{Null: true},
+		ReadOnly:  types.Bool{Value: true},
+		Serial:    types.String{Null: true},
+	}
+
+	tests := map[string]struct {
+		disks     []diskData
+		attached  []kubeberth.AttachedDisk
+		exclusive bool
+		want      []diskData
+	}{
+		"refreshes managed disks": {
+			disks:    []diskData{root},
+			attached: attached,
+			want:     []diskData{refreshedRoot},
+		},
+		"adds unmanaged disks when exclusive": {
+			disks:     []diskData{root},
+			attached:  attached,
+			exclusive: true,
+			want:      []diskData{refreshedRoot, data},
+		},
+		"drops detached disks": {
+			disks:    []diskData{root, data},
+			attached: attached[:1],
+			want:     []diskData{refreshedRoot},
+		},
+		"unset and nothing attached": {
+			exclusive: true,
+			want:      nil,
+		},
+		"set and nothing attached": {
+			disks: []diskData{root},
+			want:  []diskData{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshDisks(test.disks, test.attached, test.exclusive)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAccServerResourceDisks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceDisksConfig("virtio", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.boot_order", "1"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.bus", "virtio"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.read_only", "false"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.serial", "terraform-acc"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceDisksConfig("sata", true),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("scaffolding_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.bus", "sata"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "disks.0.read_only", "true"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -43,10 +243,714 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func testAccServerResourceDisksConfig(bus string, readOnly bool) string {
 	return fmt.Sprintf(`
-resource "scaffolding_example" "test" {
-  configurable_attribute = %[1]q
+resource "kubeberth_disk" "test" {
+  name = "terraform-acc-server-disks"
+  size = "1Gi"
+}
+
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-server-disks"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-server-disks"
+
+  disks = [
+    {
+      name       = kubeberth_disk.test.name
+      boot_order = 1
+      bus        = %[1]q
+      read_only  = %[2]t
+      serial     = "terraform-acc"
+    },
+  ]
+}
+`, bus, readOnly)
 }
-`, configurableAttribute)
+
+func TestValidateServerDisks(t *testing.T) {
+	disk := func(name string, bootOrder int64, serial string) diskData {
+		return diskData{
+			Name:      types.String{Value: name},
+			BootOrder: types.Int64{Null: bootOrder == 0, Value: bootOrder},
+			Bus:       types.String{Null: true},
+			ReadOnly:  types.Bool{Null: true},
+			Serial:    types.String{Null: serial == "", Value: serial},
+		}
+	}
+
+	tests := map[string]struct {
+		disks      []diskData
+		wantErrors int
+	}{
+		"unset": {},
+		"distinct": {
+			disks: []diskData{disk("root", 1, "root"), disk("data", 2, "data")},
+		},
+		"no boot order or serial": {
+			disks: []diskData{disk("root", 0, ""), disk("data", 0, "")},
+		},
+		"duplicate boot order": {
+			disks:      []diskData{disk("root", 1, ""), disk("data", 1, "")},
+			wantErrors: 1,
+		},
+		"duplicate serial": {
+			disks:      []diskData{disk("root", 0, "disk"), disk("data", 0, "disk")},
+			wantErrors: 1,
+		},
+		"duplicate boot order and serial": {
+			disks:      []diskData{disk("root", 1, "disk"), disk("data", 1, "disk"), disk("scratch", 1, "")},
+			wantErrors: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{}
+			if test.disks != nil {
+				attributes["disks"] = test.disks
+			}
+			config := testConfig(t, serverResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateServerDisks(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestAccServerResourceNetworkInterfaces(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceNetworkInterfacesConfig("virtio"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.#", "1"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.0.network", "default"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.0.mac_address", "52:42:00:00:00:01"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.0.model", "virtio"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.0.primary", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceNetworkInterfacesConfig("e1000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "network_interfaces.0.model", "e1000"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceNetworkInterfacesConfig(model string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-server-nics"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-server-nics"
+
+  network_interfaces = [
+    {
+      network     = "default"
+      mac_address = "52:42:00:00:00:01"
+      model       = %[1]q
+      primary     = true
+    },
+  ]
+}
+`, model)
+}
+
+func TestRefreshNetworkInterfaces(t *testing.T) {
+	nic := func(network string, macAddress string, model string) networkInterfaceData {
+		return networkInterfaceData{
+			Network:    types.String{Value: network},
+			MACAddress: types.String{Null: macAddress == "", Value: macAddress},
+			Model:      types.String{Null: model == "", Value: model},
+			Primary:    types.Bool{Null: true},
+		}
+	}
+	current := []kubeberth.NetworkInterface{
+		{Network: "default", MACAddress: "52-42-00-00-00-01", Model: nicModelE1000},
+		{Network: "storage", MACAddress: "52:42:00:00:00:02"},
+	}
+
+	tests := map[string]struct {
+		nics []networkInterfaceData
+		want []networkInterfaceData
+	}{
+		"unset": {
+			nics: nil,
+			want: nil,
+		},
+		"refreshes set attributes": {
+			nics: []networkInterfaceData{nic("default", "52:42:00:00:00:0a", nicModelVirtio), nic("storage", "", "")},
+			want: []networkInterfaceData{nic("default", "52:42:00:00:00:01", nicModelE1000), nic("storage", "", "")},
+		},
+		"appends interfaces added outside of terraform": {
+			nics: []networkInterfaceData{nic("default", "", "")},
+			want: []networkInterfaceData{
+				nic("default", "", ""),
+				{
+					Network:    types.String{Value: "storage"},
+					MACAddress: types.String{Value: "52:42:00:00:00:02"},
+					Model:      types.String{Null: true},
+					Primary:    types.Bool{Value: false},
+				},
+			},
+		},
+		"drops removed interfaces": {
+			nics: []networkInterfaceData{nic("default", "", ""), nic("storage", "", ""), nic("backup", "", "")},
+			want: []networkInterfaceData{nic("default", "", ""), nic("storage", "", "")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshNetworkInterfaces(test.nics, current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateServerNetworkInterfaces(t *testing.T) {
+	nic := func(network string, macAddress string, primary bool) networkInterfaceData {
+		return networkInterfaceData{
+			Network:    types.String{Value: network},
+			MACAddress: types.String{Null: macAddress == "", Value: macAddress},
+			Model:      types.String{Null: true},
+			Primary:    types.Bool{Value: primary},
+		}
+	}
+
+	tests := map[string]struct {
+		macAddress string
+		nics       []networkInterfaceData
+		wantErrors int
+	}{
+		"unset": {},
+		"distinct": {
+			nics: []networkInterfaceData{nic("default", "52:42:00:00:00:01", true), nic("storage", "52:42:00:00:00:02", false)},
+		},
+		"duplicate mac address in other notation": {
+			nics:       []networkInterfaceData{nic("default", "52:42:00:00:00:01", false), nic("storage", "52-42-00-00-00-01", false)},
+			wantErrors: 1,
+		},
+		"multiple primary interfaces": {
+			nics:       []networkInterfaceData{nic("default", "", true), nic("storage", "", true)},
+			wantErrors: 1,
+		},
+		"mac_address matches the first interface": {
+			macAddress: "52-42-00-00-00-01",
+			nics:       []networkInterfaceData{nic("default", "52:42:00:00:00:01", false)},
+		},
+		"mac_address conflicts with the first interface": {
+			macAddress: "52:42:00:00:00:03",
+			nics:       []networkInterfaceData{nic("default", "52:42:00:00:00:01", false)},
+			wantErrors: 1,
+		},
+		"mac_address duplicates another interface": {
+			macAddress: "52:42:00:00:00:02",
+			nics:       []networkInterfaceData{nic("default", "", false), nic("storage", "52:42:00:00:00:02", false)},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{}
+			if test.macAddress != "" {
+				attributes["mac_address"] = test.macAddress
+			}
+			if test.nics != nil {
+				attributes["network_interfaces"] = test.nics
+			}
+			config := testConfig(t, serverResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateServerNetworkInterfaces(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestAccServerResourceCPU(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceCPUConfig(1, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu", "2"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_topology.sockets", "1"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_topology.cores", "2"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_topology.threads", "1"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_model", "host-passthrough"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "dedicated_cpu", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceCPUConfig(2, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_topology.sockets", "2"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "cpu_topology.cores", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceCPUConfig(sockets int, cores int) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name          = "terraform-acc-server-cpu"
+  running       = false
+  cpu           = 2
+  cpu_model     = "host-passthrough"
+  dedicated_cpu = false
+  memory        = "1Gi"
+  hostname      = "terraform-acc-server-cpu"
+
+  cpu_topology = {
+    sockets = %[1]d
+    cores   = %[2]d
+    threads = 1
+  }
+}
+`, sockets, cores)
+}
+
+func TestValidateServerCPUTopology(t *testing.T) {
+	tests := map[string]struct {
+		cpu        int64
+		topology   *cpuTopologyData
+		wantErrors int
+	}{
+		"unset": {
+			cpu: 4,
+		},
+		"matches cpu": {
+			cpu:      8,
+			topology: &cpuTopologyData{Sockets: types.Int64{Value: 2}, Cores: types.Int64{Value: 2}, Threads: types.Int64{Value: 2}},
+		},
+		"too few vCPUs": {
+			cpu:        8,
+			topology:   &cpuTopologyData{Sockets: types.Int64{Value: 1}, Cores: types.Int64{Value: 2}, Threads: types.Int64{Value: 2}},
+			wantErrors: 1,
+		},
+		"too many vCPUs": {
+			cpu:        2,
+			topology:   &cpuTopologyData{Sockets: types.Int64{Value: 1}, Cores: types.Int64{Value: 2}, Threads: types.Int64{Value: 2}},
+			wantErrors: 1,
+		},
+		"unknown": {
+			cpu:      2,
+			topology: &cpuTopologyData{Sockets: types.Int64{Unknown: true}, Cores: types.Int64{Value: 2}, Threads: types.Int64{Value: 2}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{"cpu": test.cpu}
+			if test.topology != nil {
+				attributes["cpu_topology"] = test.topology
+			}
+			config := testConfig(t, serverResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateServerCPUTopology(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestAccServerResourceFirmware(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceFirmwareConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "firmware", "uefi"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "secure_boot", "false"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "tpm", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceFirmwareConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "secure_boot", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceFirmwareConfig(secureBoot bool) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name        = "terraform-acc-server-firmware"
+  running     = false
+  cpu         = 1
+  memory      = "1Gi"
+  hostname    = "terraform-acc-server-firmware"
+  firmware    = "uefi"
+  secure_boot = %[1]t
+  tpm         = true
+}
+`, secureBoot)
+}
+
+func TestValidateServerFirmware(t *testing.T) {
+	tests := map[string]struct {
+		firmware   string
+		secureBoot *bool
+		wantErrors int
+	}{
+		"unset": {},
+		"uefi": {
+			firmware: firmwareUEFI,
+		},
+		"secure boot with uefi": {
+			firmware:   firmwareUEFI,
+			secureBoot: boolPointer(true),
+		},
+		"secure boot with bios": {
+			firmware:   firmwareBIOS,
+			secureBoot: boolPointer(true),
+			wantErrors: 1,
+		},
+		"secure boot without firmware": {
+			secureBoot: boolPointer(true),
+			wantErrors: 1,
+		},
+		"secure boot disabled with bios": {
+			firmware:   firmwareBIOS,
+			secureBoot: boolPointer(false),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{}
+			if test.firmware != "" {
+				attributes["firmware"] = test.firmware
+			}
+			if test.secureBoot != nil {
+				attributes["secure_boot"] = *test.secureBoot
+			}
+			config := testConfig(t, serverResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateServerFirmware(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func TestAccServerResourceMemory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceMemoryConfig("512Mi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "memory", "2Gi"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "memory_request", "512Mi"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "hugepages", "2Mi"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceMemoryConfig("1Gi"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "memory_request", "1Gi"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceMemoryConfig(memoryRequest string) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name           = "terraform-acc-server-memory"
+  running        = false
+  cpu            = 1
+  memory         = "2Gi"
+  memory_request = %[1]q
+  hugepages      = "2Mi"
+  hostname       = "terraform-acc-server-memory"
+}
+`, memoryRequest)
+}
+
+func TestValidateServerMemory(t *testing.T) {
+	tests := map[string]struct {
+		memory        string
+		memoryRequest string
+		hugePages     string
+		wantErrors    int
+	}{
+		"memory only": {
+			memory: "1Gi",
+		},
+		"request below memory": {
+			memory:        "1Gi",
+			memoryRequest: "512Mi",
+		},
+		"request equal to memory in other units": {
+			memory:        "1Gi",
+			memoryRequest: "1024Mi",
+		},
+		"request above memory": {
+			memory:        "1Gi",
+			memoryRequest: "2Gi",
+			wantErrors:    1,
+		},
+		"multiple of hugepages": {
+			memory:    "2Gi",
+			hugePages: hugePages1Gi,
+		},
+		"not a multiple of hugepages": {
+			memory:     "1536Mi",
+			hugePages:  hugePages1Gi,
+			wantErrors: 1,
+		},
+		"invalid memory": {
+			memory:        "lots",
+			memoryRequest: "2Gi",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]interface{}{"memory": test.memory}
+			if test.memoryRequest != "" {
+				attributes["memory_request"] = test.memoryRequest
+			}
+			if test.hugePages != "" {
+				attributes["hugepages"] = test.hugePages
+			}
+			config := testConfig(t, serverResourceType{}, attributes)
+
+			var diags diag.Diagnostics
+			validateServerMemory(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestAccServerResourceScheduling(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServerResourceSchedulingConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "node_selector.kubernetes.io/os", "linux"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "tolerations.0.key", "kubeberth.io/dedicated"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "tolerations.0.operator", "Exists"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "tolerations.0.effect", "NoSchedule"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "anti_affinity.servers.0", "terraform-acc-server-scheduling-peer"),
+					resource.TestCheckResourceAttr("kubeberth_server.test", "anti_affinity.required", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccServerResourceSchedulingConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubeberth_server.test", "anti_affinity.required", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServerResourceSchedulingConfig(required bool) string {
+	return fmt.Sprintf(`
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-server-scheduling"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-server-scheduling"
+
+  node_selector = {
+    "kubernetes.io/os" = "linux"
+  }
+
+  tolerations = [
+    {
+      key      = "kubeberth.io/dedicated"
+      operator = "Exists"
+      effect   = "NoSchedule"
+    },
+  ]
+
+  anti_affinity = {
+    servers  = ["terraform-acc-server-scheduling-peer"]
+    required = %[1]t
+  }
+}
+`, required)
+}
+
+func TestValidateServerScheduling(t *testing.T) {
+	toleration := func(key string, operator string, value string) tolerationData {
+		return tolerationData{
+			Key:      types.String{Null: key == "", Value: key},
+			Operator: types.String{Value: operator},
+			Value:    types.String{Null: value == "", Value: value},
+			Effect:   types.String{Null: true},
+		}
+	}
+
+	tests := map[string]struct {
+		attributes map[string]interface{}
+		wantErrors int
+	}{
+		"unset": {
+			attributes: map[string]interface{}{},
+		},
+		"hosting": {
+			attributes: map[string]interface{}{"hosting": "node-1"},
+		},
+		"hosting with node_selector": {
+			attributes: map[string]interface{}{
+				"hosting":       "node-1",
+				"node_selector": map[string]string{"disk": "ssd"},
+			},
+			wantErrors: 1,
+		},
+		"hosting with affinity and anti_affinity": {
+			attributes: map[string]interface{}{
+				"hosting":       "node-1",
+				"affinity":      &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Null: true}},
+				"anti_affinity": &affinityData{Servers: []string{"web-2"}, Required: types.Bool{Null: true}},
+			},
+			wantErrors: 2,
+		},
+		"hosting with tolerations": {
+			attributes: map[string]interface{}{
+				"hosting":     "node-1",
+				"tolerations": []tolerationData{toleration("dedicated", tolerationOpEqual, "kubeberth")},
+			},
+		},
+		"exists without key": {
+			attributes: map[string]interface{}{
+				"tolerations": []tolerationData{toleration("", tolerationOpExists, "")},
+			},
+		},
+		"exists with value": {
+			attributes: map[string]interface{}{
+				"tolerations": []tolerationData{toleration("dedicated", tolerationOpExists, "kubeberth")},
+			},
+			wantErrors: 1,
+		},
+		"equal without key": {
+			attributes: map[string]interface{}{
+				"tolerations": []tolerationData{toleration("", tolerationOpEqual, "kubeberth")},
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, serverResourceType{}, test.attributes)
+
+			var diags diag.Diagnostics
+			validateServerScheduling(context.Background(), config, &diags)
+
+			if got := testErrorCount(diags); got != test.wantErrors {
+				t.Errorf("expected %d errors, got %v", test.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestRefreshAffinityData(t *testing.T) {
+	tests := map[string]struct {
+		data     *affinityData
+		affinity *kubeberth.Affinity
+		want     *affinityData
+	}{
+		"unset": {
+			affinity: &kubeberth.Affinity{Servers: []string{"db-1"}, Required: true},
+			want:     nil,
+		},
+		"removed outside of terraform": {
+			data: &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Null: true}},
+			want: &affinityData{Required: types.Bool{Null: true}},
+		},
+		"default required": {
+			data:     &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Null: true}},
+			affinity: &kubeberth.Affinity{Servers: []string{"db-2"}, Required: true},
+			want:     &affinityData{Servers: []string{"db-2"}, Required: types.Bool{Null: true}},
+		},
+		"preferred outside of terraform": {
+			data:     &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Null: true}},
+			affinity: &kubeberth.Affinity{Servers: []string{"db-1"}},
+			want:     &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Value: false}},
+		},
+		"node labels added outside of terraform": {
+			data:     &affinityData{Servers: []string{"db-1"}, Required: types.Bool{Value: true}},
+			affinity: &kubeberth.Affinity{NodeLabels: map[string]string{"disk": "ssd"}, Servers: []string{"db-1"}, Required: true},
+			want:     &affinityData{NodeLabels: map[string]string{"disk": "ssd"}, Servers: []string{"db-1"}, Required: types.Bool{Value: true}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := refreshAffinityData(test.data, test.affinity)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
 }
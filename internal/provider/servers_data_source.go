@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = serversDataSourceType{}
+var _ tfsdk.DataSource = serversDataSource{}
+
+type serversDataSourceType struct{}
+
+func (t serversDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := listFilterAttributes()
+	attributes["hosting"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return servers placed on this node.",
+		Type:                types.StringType,
+		Optional:            true,
+	}
+	attributes["running"] = tfsdk.Attribute{
+		MarkdownDescription: "Only return servers in this power state.",
+		Type:                types.BoolType,
+		Optional:            true,
+	}
+	attributes["servers"] = tfsdk.Attribute{
+		MarkdownDescription: "Matching servers, sorted by name.",
+		Computed:            true,
+		Attributes:          tfsdk.ListNestedAttributes(serverAttributes(), tfsdk.ListNestedAttributesOptions{}),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists kubeberth servers.",
+
+		Attributes: attributes,
+	}, nil
+}
+
+func (t serversDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return serversDataSource{
+		provider: provider,
+	}, diags
+}
+
+type serversDataSourceData struct {
+	NameRegex types.String           `tfsdk:"name_regex"`
+	Labels    map[string]string      `tfsdk:"labels"`
+	Hosting   types.String           `tfsdk:"hosting"`
+	Running   types.Bool             `tfsdk:"running"`
+	Servers   []serverDataSourceData `tfsdk:"servers"`
+}
+
+// match reports whether server passes the filters of the data source.
+func (data *serversDataSourceData) match(filter *listFilter, server *kubeberth.ResponseServer) bool {
+	if !filter.match(server.Name, server.Labels) {
+		return false
+	}
+	if !data.Hosting.Null && server.Hosting != data.Hosting.Value {
+		return false
+	}
+	if !data.Running.Null && server.Running != data.Running.Value {
+		return false
+	}
+
+	return true
+}
+
+type serversDataSource struct {
+	provider provider
+}
+
+func (d serversDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data serversDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("name_regex"), "Invalid Attribute Value", err.Error())
+		return
+	}
+
+	responseServers, err := d.provider.client.GetAllServers(ctx)
+	tflog.Trace(ctx, fmt.Sprintf("servers: %+v\n", responseServers))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list servers, got error: %s", err))
+		return
+	}
+
+	data.Servers = []serverDataSourceData{}
+	for i := range responseServers {
+		server := &responseServers[i]
+		if !data.match(filter, server) {
+			continue
+		}
+
+		item, err := newServerDataSourceData(server)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server %q, got error: %s", server.Name, err))
+			return
+		}
+		data.Servers = append(data.Servers, *item)
+	}
+
+	sort.Slice(data.Servers, func(i, j int) bool {
+		return data.Servers[i].Name.Value < data.Servers[j].Name.Value
+	})
+
+	tflog.Trace(ctx, "read a data source")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
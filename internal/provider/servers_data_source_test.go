@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kubeberth/kubeberth-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccServersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccServersDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubeberth_servers.test", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kubeberth_servers.test", "servers.0.name", "kubeberth_server.test", "name"),
+					resource.TestCheckResourceAttr("data.kubeberth_servers.test", "servers.0.running", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServersDataSourceConfig = `
+resource "kubeberth_server" "test" {
+  name     = "terraform-acc-servers"
+  running  = false
+  cpu      = 1
+  memory   = "1Gi"
+  hostname = "terraform-acc-servers"
+}
+
+data "kubeberth_servers" "test" {
+  name_regex = "^${kubeberth_server.test.name}$"
+  running    = false
+}
+`
+
+func TestServersDataSourceMatch(t *testing.T) {
+	server := &kubeberth.ResponseServer{
+		Name:    "web-1",
+		Hosting: "node-1",
+		Running: true,
+	}
+
+	tests := map[string]struct {
+		data serversDataSourceData
+		want bool
+	}{
+		"no filters": {
+			data: serversDataSourceData{Hosting: types.String{Null: true}, Running: types.Bool{Null: true}},
+			want: true,
+		},
+		"hosting matches": {
+			data: serversDataSourceData{Hosting: types.String{Value: "node-1"}, Running: types.Bool{Null: true}},
+			want: true,
+		},
+		"hosting differs": {
+			data: serversDataSourceData{Hosting: types.String{Value: "node-2"}, Running: types.Bool{Null: true}},
+			want: false,
+		},
+		"running matches": {
+			data: serversDataSourceData{Hosting: types.String{Null: true}, Running: types.Bool{Value: true}},
+			want: true,
+		},
+		"running differs": {
+			data: serversDataSourceData{Hosting: types.String{Null: true}, Running: types.Bool{Value: false}},
+			want: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.data.match(testListFilter(t), server); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
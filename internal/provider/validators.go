@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringValidator adapts a function checking a single known string value to
+// the tfsdk.AttributeValidator interface. Null and unknown values are skipped.
+type stringValidator struct {
+	description string
+	validate    func(value string) error
+}
+
+func (v stringValidator) Description(ctx context.Context) string {
+	return v.description
+}
+
+func (v stringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.description
+}
+
+func (v stringValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	if err := v.validate(value.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Attribute Value", err.Error())
+	}
+}
+
+// stringOneOf checks that the value is one of the given values.
+func stringOneOf(values ...string) tfsdk.AttributeValidator {
+	return stringValidator{
+		description: fmt.Sprintf("value must be one of: %s", strings.Join(values, ", ")),
+		validate: func(value string) error {
+			for _, v := range values {
+				if value == v {
+					return nil
+				}
+			}
+			return fmt.Errorf("%q is not one of: %s", value, strings.Join(values, ", "))
+		},
+	}
+}
+
+// durationString checks that the value parses as a positive time.Duration.
+func durationString() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be a duration such as `30s` or `5m`",
+		validate: func(value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a valid duration: %s", value, err)
+			}
+			if d <= 0 {
+				return fmt.Errorf("%q must be greater than zero", value)
+			}
+			return nil
+		},
+	}
+}
+
+// quantityString checks that the value parses as a positive Kubernetes
+// resource quantity.
+func quantityString() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be a quantity such as `512Mi` or `4Gi`",
+		validate: func(value string) error {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a valid quantity: %s", value, err)
+			}
+			if quantity.Sign() <= 0 {
+				return fmt.Errorf("%q must be greater than zero", value)
+			}
+			return nil
+		},
+	}
+}
+
+// stringMatches checks that the value matches re. message describes the
+// expected format to the user.
+func stringMatches(re *regexp.Regexp, message string) tfsdk.AttributeValidator {
+	return stringValidator{
+		description: message,
+		validate: func(value string) error {
+			if !re.MatchString(value) {
+				return fmt.Errorf("%q is invalid: %s", value, message)
+			}
+			return nil
+		},
+	}
+}
+
+// int64Validator checks that a known Int64 value lies within [min, max].
+type int64Validator struct {
+	min int64
+	max int64
+}
+
+func (v int64Validator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v int64Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64Validator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.Int64
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	if value.Value < v.min || value.Value > v.max {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Attribute Value", fmt.Sprintf("%d is not between %d and %d", value.Value, v.min, v.max))
+	}
+}
+
+// int64Between checks that the value lies within [min, max].
+func int64Between(min, max int64) tfsdk.AttributeValidator {
+	return int64Validator{min: min, max: max}
+}
+
+// macAddressValidator checks that the value is a unicast, locally
+// administered 48-bit MAC address. Globally administered addresses belong to
+// hardware vendors and may clash with real hardware on the same network.
+type macAddressValidator struct{}
+
+func (v macAddressValidator) Description(ctx context.Context) string {
+	return "value must be a unicast, locally administered MAC address such as `52:42:00:11:22:33`"
+}
+
+func (v macAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v macAddressValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	mac, err := parseMACAddress(value.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid MAC Address", err.Error())
+		return
+	}
+
+	if macAddressMulticast(mac) {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid MAC Address",
+			fmt.Sprintf("%q is a multicast address; the lowest bit of the first octet must be clear.", value.Value))
+		return
+	}
+
+	if !macAddressLocal(mac) {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid MAC Address",
+			fmt.Sprintf("%q is globally administered and may clash with real hardware; the second lowest bit of the first octet must be set, e.g. `52:...`.", value.Value))
+	}
+}
+
+// macAddress checks that the value is a unicast, locally administered MAC
+// address.
+func macAddress() tfsdk.AttributeValidator {
+	return macAddressValidator{}
+}
+
+// ipAddress checks that the value is an IPv4 or IPv6 address.
+func ipAddress() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be an IP address such as `192.0.2.10`",
+		validate: func(value string) error {
+			if net.ParseIP(value) == nil {
+				return fmt.Errorf("%q is not a valid IP address", value)
+			}
+			return nil
+		},
+	}
+}
+
+// cidrString checks that the value is an IPv4 or IPv6 network in CIDR
+// notation.
+func cidrString() stringValidator {
+	return stringValidator{
+		description: "value must be a CIDR such as `192.0.2.0/24`",
+		validate: func(value string) error {
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				return fmt.Errorf("%q is not a valid CIDR: %s", value, err)
+			}
+			return nil
+		},
+	}
+}
+
+// stringListValidator applies a stringValidator to every known element of a
+// list of strings.
+type stringListValidator struct {
+	element stringValidator
+}
+
+func (v stringListValidator) Description(ctx context.Context) string {
+	return "each " + v.element.description
+}
+
+func (v stringListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringListValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.List
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	for i, elem := range value.Elems {
+		s, ok := elem.(types.String)
+		if !ok || s.Null || s.Unknown {
+			continue
+		}
+
+		if err := v.element.validate(s.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(req.AttributePath.WithElementKeyInt(i), "Invalid Attribute Value", err.Error())
+		}
+	}
+}
+
+// eachString checks every element of a list of strings with element.
+func eachString(element stringValidator) tfsdk.AttributeValidator {
+	return stringListValidator{element: element}
+}
+
+// repositoryURL checks that the value is an absolute http, https, s3 or oci
+// URL naming a host, bucket or registry. A value without a scheme, usually a
+// container image reference such as `registry/repository:tag`, is rejected
+// with a hint to add `oci://`.
+func repositoryURL() tfsdk.AttributeValidator {
+	return stringValidator{
+		description: "value must be an `http://`, `https://`, `s3://` or `oci://` URL",
+		validate: func(value string) error {
+			if !strings.Contains(value, "://") {
+				return fmt.Errorf("%q has no scheme; write a container image as %q, or a file as an http, https or s3 URL", value, "oci://"+value)
+			}
+
+			u, err := url.Parse(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a valid URL: %s", value, err)
+			}
+
+			switch u.Scheme {
+			case "http", "https", "s3", "oci":
+			default:
+				return fmt.Errorf("%q must use one of the schemes http, https, s3 or oci", value)
+			}
+
+			if u.Host == "" {
+				return fmt.Errorf("%q does not name a host, bucket or registry", value)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" && strings.Trim(u.Path, "/") == "" {
+				return fmt.Errorf("%q does not name an object or image", value)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testValidateString runs validator on value and returns the error it
+// reports, if any.
+func testValidateString(t *testing.T, validator tfsdk.AttributeValidator, value string) string {
+	t.Helper()
+
+	req := tfsdk.ValidateAttributeRequest{
+		AttributePath:   tftypes.NewAttributePath().WithAttributeName("test"),
+		AttributeConfig: types.String{Value: value},
+	}
+	resp := &tfsdk.ValidateAttributeResponse{}
+
+	validator.Validate(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		return ""
+	}
+
+	var details []string
+	for _, d := range resp.Diagnostics {
+		details = append(details, d.Detail())
+	}
+
+	return strings.Join(details, "\n")
+}
+
+func TestRepositoryURL(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		wantError string
+	}{
+		"http": {
+			value: "http://minio.home.arpa:9000/kubevirt/images/ubuntu.img",
+		},
+		"https": {
+			value: "https://cloud-images.ubuntu.com/jammy/current/jammy-server-cloudimg-amd64.img",
+		},
+		"s3": {
+			value: "s3://images/ubuntu.img",
+		},
+		"oci": {
+			value: "oci://registry.example.com/images/ubuntu:22.04",
+		},
+		"registry reference": {
+			value:     "registry.example.com/images/ubuntu:22.04",
+			wantError: `"oci://registry.example.com/images/ubuntu:22.04"`,
+		},
+		"registry reference with port": {
+			value:     "registry.example.com:5000/ubuntu:22.04",
+			wantError: `"oci://registry.example.com:5000/ubuntu:22.04"`,
+		},
+		"unsupported scheme": {
+			value:     "ftp://example.com/ubuntu.img",
+			wantError: "must use one of the schemes",
+		},
+		"no host": {
+			value:     "https:///ubuntu.img",
+			wantError: "does not name a host",
+		},
+		"no image": {
+			value:     "oci://registry.example.com/",
+			wantError: "does not name an object or image",
+		},
+		"no object": {
+			value:     "s3://images",
+			wantError: "does not name an object or image",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := testValidateString(t, repositoryURL(), test.value)
+			if test.wantError == "" && got != "" {
+				t.Fatalf("unexpected error: %s", got)
+			}
+			if !strings.Contains(got, test.wantError) {
+				t.Errorf("expected an error containing %s, got %q", test.wantError, got)
+			}
+		})
+	}
+}